@@ -1,13 +1,272 @@
 package auth
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"sync"
+	"time"
+)
 
-func HashPassword(rawPassword string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(rawPassword), bcrypt.DefaultCost)
+// bcrypt only uses the first 72 bytes of a password and silently ignores the rest.
+const bcryptMaxPasswordBytes = 72
+
+var (
+	ErrPasswordTooLong     = errors.New("password is too long for the hash algorithm")
+	ErrUnknownHashEncoding = errors.New("password hash encoding is not recognized")
+)
+
+// Hasher hashes passwords into self-describing encoded hashes in the PHC string format
+// ($<id>$<params>$<salt>$<hash>), so hashes made with other algorithms or parameters can
+// be told apart and upgraded.
+type Hasher interface {
+	// Hash returns the encoded hash of password.
+	Hash(password string) (string, error)
+	// Verify reports whether password matches encodedHash.
+	Verify(encodedHash, password string) (bool, error)
+	// Recognizes reports whether encodedHash was made by this algorithm.
+	Recognizes(encodedHash string) bool
+	// NeedsRehash reports whether encodedHash was made with different parameters than the
+	// Hasher's current ones.
+	NeedsRehash(encodedHash string) bool
+}
+
+// Argon2idParams are the argon2id cost parameters. Memory is in KiB.
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2idParams follow the RFC 9106 recommendation for memory constrained environments.
+var DefaultArgon2idParams = Argon2idParams{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+type Argon2idHasher struct {
+	Params Argon2idParams
+}
+
+func NewArgon2idHasher(params Argon2idParams) *Argon2idHasher {
+	return &Argon2idHasher{Params: params}
+}
+
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.Params.SaltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Params.Iterations, h.Params.Memory, h.Params.Parallelism, h.Params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.Params.Memory, h.Params.Iterations, h.Params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h *Argon2idHasher) Verify(encodedHash, password string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(encodedHash)
+	if err != nil {
+		return false, err
+	}
+
+	otherKey := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return subtle.ConstantTimeCompare(key, otherKey) == 1, nil
+}
+
+func (h *Argon2idHasher) Recognizes(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$argon2id$")
+}
+
+func (h *Argon2idHasher) NeedsRehash(encodedHash string) bool {
+	params, salt, _, err := decodeArgon2id(encodedHash)
+	if err != nil {
+		return true
+	}
+
+	return params.Memory != h.Params.Memory ||
+		params.Iterations != h.Params.Iterations ||
+		params.Parallelism != h.Params.Parallelism ||
+		params.KeyLength != h.Params.KeyLength ||
+		uint32(len(salt)) != h.Params.SaltLength
+}
+
+func decodeArgon2id(encodedHash string) (Argon2idParams, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=65536,t=3,p=2", salt, key
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return Argon2idParams{}, nil, nil, ErrUnknownHashEncoding
+	}
+
+	var version int
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return Argon2idParams{}, nil, nil, fmt.Errorf("unsupported argon2id version %s", parts[2])
+	}
+
+	var params Argon2idParams
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		return Argon2idParams{}, nil, nil, fmt.Errorf("malformed argon2id parameters: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2idParams{}, nil, nil, fmt.Errorf("malformed argon2id salt: %w", err)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Argon2idParams{}, nil, nil, fmt.Errorf("malformed argon2id hash: %w", err)
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
+}
+
+// BcryptHasher produces bcrypt hashes in their $2a$<cost>$ modular crypt format, which
+// predates but follows the PHC string format.
+type BcryptHasher struct {
+	Cost int
+}
+
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{Cost: cost}
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	if len(password) > bcryptMaxPasswordBytes {
+		return "", ErrPasswordTooLong
+	}
+
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
 	return string(bytes), err
 }
 
-func CheckPasswordHash(hashedPassword, rawPassword string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(rawPassword))
-	return err == nil
+func (h *BcryptHasher) Verify(encodedHash, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (h *BcryptHasher) Recognizes(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$2a$") ||
+		strings.HasPrefix(encodedHash, "$2b$") ||
+		strings.HasPrefix(encodedHash, "$2y$")
+}
+
+func (h *BcryptHasher) NeedsRehash(encodedHash string) bool {
+	cost, err := bcrypt.Cost([]byte(encodedHash))
+	return err != nil || cost != h.Cost
+}
+
+// PasswordHasher hashes new passwords with its primary Hasher, and verifies passwords
+// against hashes made by any of its Hashers so older hashes can be upgraded on login.
+// Every check takes at least as long as verifying a hash of its slowest Hasher, so the
+// timing of checks doesn't tell which algorithm a user's hash was made with.
+type PasswordHasher struct {
+	Primary Hasher
+	Others  []Hasher
+
+	dummyOnce sync.Once
+	// dummyHash is a hash of dummyHasher, the slowest Hasher, which took dummyDuration
+	// to verify.
+	dummyHasher   Hasher
+	dummyHash     string
+	dummyDuration time.Duration
+}
+
+// NewPasswordHasher returns a PasswordHasher hashing with primary. Hashes made by others
+// are still verified but reported as needing a rehash.
+func NewPasswordHasher(primary Hasher, others ...Hasher) *PasswordHasher {
+	return &PasswordHasher{
+		Primary: primary,
+		Others:  others,
+	}
+}
+
+func (p *PasswordHasher) Hash(password string) (string, error) {
+	return p.Primary.Hash(password)
+}
+
+// Verify reports whether password matches encodedHash, and if so whether the hash should
+// be replaced by a new Hash of the password.
+func (p *PasswordHasher) Verify(encodedHash, password string) (bool, bool, error) {
+	defer p.padDuration(time.Now())
+
+	if p.Primary.Recognizes(encodedHash) {
+		ok, err := p.Primary.Verify(encodedHash, password)
+		return ok, ok && p.Primary.NeedsRehash(encodedHash), err
+	}
+
+	for _, hasher := range p.Others {
+		if hasher.Recognizes(encodedHash) {
+			ok, err := hasher.Verify(encodedHash, password)
+			return ok, ok, err
+		}
+	}
+
+	return false, false, ErrUnknownHashEncoding
+}
+
+// VerifyDummy checks password against a hash of the slowest Hasher that no password
+// matches, taking as long as verifying a real hash. Checks of unknown users call it so
+// their timing doesn't tell them apart from wrong passwords.
+func (p *PasswordHasher) VerifyDummy(password string) {
+	defer p.padDuration(time.Now())
+
+	p.prepareDummy()
+	if p.dummyHasher != nil {
+		_, _ = p.dummyHasher.Verify(p.dummyHash, password)
+	}
+}
+
+// prepareDummy hashes a random password with every Hasher, keeping the hash of the one
+// slowest to verify it.
+func (p *PasswordHasher) prepareDummy() {
+	p.dummyOnce.Do(func() {
+		secret := make([]byte, 24)
+		_, err := rand.Read(secret)
+		if err != nil {
+			return
+		}
+		password := base64.RawStdEncoding.EncodeToString(secret)
+
+		for _, hasher := range append([]Hasher{p.Primary}, p.Others...) {
+			hash, err := hasher.Hash(password)
+			if err != nil {
+				continue
+			}
+
+			start := time.Now()
+			_, _ = hasher.Verify(hash, "")
+			elapsed := time.Since(start)
+			if p.dummyHasher == nil || elapsed > p.dummyDuration {
+				p.dummyHasher, p.dummyHash, p.dummyDuration = hasher, hash, elapsed
+			}
+		}
+	})
+}
+
+// padDuration waits until a check started at start took as long as verifying a hash of
+// the slowest Hasher.
+func (p *PasswordHasher) padDuration(start time.Time) {
+	p.prepareDummy()
+	time.Sleep(p.dummyDuration - time.Since(start))
 }
@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testArgon2idParams = Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestHashers(t *testing.T) {
+	hashers := map[string]Hasher{
+		"argon2id": NewArgon2idHasher(testArgon2idParams),
+		"bcrypt":   NewBcryptHasher(bcrypt.MinCost),
+	}
+
+	for name, hasher := range hashers {
+		t.Run(name, func(t *testing.T) {
+			encodedHash, err := hasher.Hash("Raid3r!Password")
+			if err != nil {
+				t.Fatalf("hashing: %v", err)
+			}
+
+			if !hasher.Recognizes(encodedHash) {
+				t.Errorf("hash %s is not recognized", encodedHash)
+			}
+			if hasher.NeedsRehash(encodedHash) {
+				t.Errorf("fresh hash %s needs a rehash", encodedHash)
+			}
+
+			ok, err := hasher.Verify(encodedHash, "Raid3r!Password")
+			if err != nil || !ok {
+				t.Errorf("correct password did not verify: %v", err)
+			}
+
+			ok, err = hasher.Verify(encodedHash, "Wr0ng!Password")
+			if err != nil || ok {
+				t.Errorf("wrong password verified: %v", err)
+			}
+		})
+	}
+}
+
+func TestArgon2idEncoding(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2idParams)
+
+	encodedHash, err := hasher.Hash("Raid3r!Password")
+	if err != nil {
+		t.Fatalf("hashing: %v", err)
+	}
+	if !strings.HasPrefix(encodedHash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("unexpected encoding %s", encodedHash)
+	}
+
+	stronger := testArgon2idParams
+	stronger.Iterations = 2
+	if !NewArgon2idHasher(stronger).NeedsRehash(encodedHash) {
+		t.Errorf("hash with outdated parameters does not need a rehash")
+	}
+
+	_, err = hasher.Verify("$argon2id$v=19$m=1024$salt$hash", "Raid3r!Password")
+	if err == nil {
+		t.Errorf("malformed hash did not fail")
+	}
+}
+
+func TestBcryptRejectsLongPasswords(t *testing.T) {
+	_, err := NewBcryptHasher(bcrypt.MinCost).Hash(strings.Repeat("a", 73))
+	if err != ErrPasswordTooLong {
+		t.Errorf("got %v, want ErrPasswordTooLong", err)
+	}
+}
+
+func TestPasswordHasherUpgradesHashes(t *testing.T) {
+	bcryptHasher := NewBcryptHasher(bcrypt.MinCost)
+	passwords := NewPasswordHasher(NewArgon2idHasher(testArgon2idParams), bcryptHasher)
+
+	bcryptHash, err := bcryptHasher.Hash("Raid3r!Password")
+	if err != nil {
+		t.Fatalf("hashing: %v", err)
+	}
+
+	ok, needsRehash, err := passwords.Verify(bcryptHash, "Raid3r!Password")
+	if err != nil || !ok || !needsRehash {
+		t.Errorf("got ok=%v needsRehash=%v err=%v, want a match needing a rehash", ok, needsRehash, err)
+	}
+
+	ok, needsRehash, err = passwords.Verify(bcryptHash, "Wr0ng!Password")
+	if err != nil || ok || needsRehash {
+		t.Errorf("got ok=%v needsRehash=%v err=%v, want no match", ok, needsRehash, err)
+	}
+
+	argon2idHash, err := passwords.Hash("Raid3r!Password")
+	if err != nil {
+		t.Fatalf("hashing: %v", err)
+	}
+
+	ok, needsRehash, err = passwords.Verify(argon2idHash, "Raid3r!Password")
+	if err != nil || !ok || needsRehash {
+		t.Errorf("got ok=%v needsRehash=%v err=%v, want a match", ok, needsRehash, err)
+	}
+
+	_, _, err = passwords.Verify("plaintext", "plaintext")
+	if err != ErrUnknownHashEncoding {
+		t.Errorf("got %v, want ErrUnknownHashEncoding", err)
+	}
+}
+
+func TestPasswordHasherVerifyDummy(t *testing.T) {
+	passwords := NewPasswordHasher(NewArgon2idHasher(testArgon2idParams))
+
+	passwords.VerifyDummy("Raid3r!Password")
+	if !passwords.Primary.Recognizes(passwords.dummyHash) {
+		t.Errorf("got dummy hash %q, want a hash of the primary hasher", passwords.dummyHash)
+	}
+
+	ok, _, err := passwords.Verify(passwords.dummyHash, "")
+	if err != nil || ok {
+		t.Errorf("got ok=%v err=%v, want no match", ok, err)
+	}
+}
+
+// slowHasher is a Hasher taking at least delay to verify hashes.
+type slowHasher struct {
+	Hasher
+	delay time.Duration
+}
+
+func (h slowHasher) Verify(encodedHash, password string) (bool, error) {
+	time.Sleep(h.delay)
+	return h.Hasher.Verify(encodedHash, password)
+}
+
+func TestPasswordHasherTakesAsLongAsSlowestHasher(t *testing.T) {
+	argon2idHasher := NewArgon2idHasher(testArgon2idParams)
+	slow := slowHasher{Hasher: &BcryptHasher{Cost: bcrypt.MinCost}, delay: 50 * time.Millisecond}
+	passwords := NewPasswordHasher(argon2idHasher, slow)
+
+	hash, err := argon2idHasher.Hash("Raid3r!Password")
+	if err != nil {
+		t.Fatalf("hashing: %v", err)
+	}
+
+	start := time.Now()
+	passwords.VerifyDummy("Raid3r!Password")
+	if elapsed := time.Since(start); elapsed < slow.delay {
+		t.Errorf("dummy check took %v, want at least as long as the slowest hasher", elapsed)
+	}
+	if !slow.Recognizes(passwords.dummyHash) {
+		t.Errorf("got dummy hash %q, want a hash of the slowest hasher", passwords.dummyHash)
+	}
+
+	start = time.Now()
+	ok, _, err := passwords.Verify(hash, "Raid3r!Password")
+	if err != nil || !ok {
+		t.Fatalf("got ok=%v err=%v, want a match", ok, err)
+	}
+	if elapsed := time.Since(start); elapsed < slow.delay {
+		t.Errorf("check of an argon2id hash took %v, want at least as long as the slowest hasher", elapsed)
+	}
+}
@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const minRSAKeyBits = 2048
+
+// SigningKey is a private key access tokens are signed with, identified by its kid.
+// Keys are either Ed25519 (signing with EdDSA) or RSA (signing with RS256).
+type SigningKey struct {
+	ID         string
+	PrivateKey crypto.Signer
+}
+
+// Algorithm returns the JWS algorithm the key signs with.
+func (k SigningKey) Algorithm() string {
+	if _, ok := k.PrivateKey.(*rsa.PrivateKey); ok {
+		return "RS256"
+	}
+	return "EdDSA"
+}
+
+// JSONWebKey is the public part of a SigningKey as published in a JWKS.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWK returns the public JSON Web Key for k.
+func (k SigningKey) JWK() JSONWebKey {
+	jwk := JSONWebKey{
+		KeyID:     k.ID,
+		Use:       "sig",
+		Algorithm: k.Algorithm(),
+	}
+
+	switch publicKey := k.PrivateKey.Public().(type) {
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	}
+
+	return jwk
+}
+
+// KeySet holds the keys access tokens are signed and verified with. Every key in the
+// set is published and accepted for verification, while only the active key signs new
+// tokens. Rotating keys is done by adding a key, reloading, and only then making it active
+// so downstream services have a chance to fetch it first.
+type KeySet struct {
+	dir         string
+	activeKeyID string
+
+	mu     sync.RWMutex
+	keys   map[string]SigningKey
+	active SigningKey
+}
+
+// LoadKeySet loads every <kid>.pem private key in dir. activeKeyID selects the key used
+// to sign new tokens, when empty the last kid in lexical order is used so date-based kids
+// (e.g. 2022-11-01) rotate by adding a newer key.
+func LoadKeySet(dir, activeKeyID string) (*KeySet, error) {
+	keySet := &KeySet{
+		dir:         dir,
+		activeKeyID: activeKeyID,
+	}
+
+	err := keySet.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return keySet, nil
+}
+
+// NewEphemeralKeySet returns a KeySet with a single generated Ed25519 key for local
+// development. Tokens signed with it can't be verified after a restart.
+func NewEphemeralKeySet() (*KeySet, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	key := SigningKey{ID: "ephemeral", PrivateKey: privateKey}
+	return &KeySet{
+		keys:   map[string]SigningKey{key.ID: key},
+		active: key,
+	}, nil
+}
+
+// Reload reads the keys from disk again. The current keys are kept if loading fails.
+func (s *KeySet) Reload() error {
+	if s.dir == "" {
+		return nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.pem"))
+	if err != nil {
+		return err
+	}
+
+	keys := map[string]SigningKey{}
+	var keyIDs []string
+	for _, path := range paths {
+		keyID := strings.TrimSuffix(filepath.Base(path), ".pem")
+		privateKey, err := readPrivateKey(path)
+		if err != nil {
+			return fmt.Errorf("loading signing key %s: %w", path, err)
+		}
+
+		keys[keyID] = SigningKey{ID: keyID, PrivateKey: privateKey}
+		keyIDs = append(keyIDs, keyID)
+	}
+
+	if len(keyIDs) == 0 {
+		return fmt.Errorf("no signing keys found in %s", s.dir)
+	}
+
+	sort.Strings(keyIDs)
+	activeKeyID := s.activeKeyID
+	if activeKeyID == "" {
+		activeKeyID = keyIDs[len(keyIDs)-1]
+	}
+
+	active, ok := keys[activeKeyID]
+	if !ok {
+		return fmt.Errorf("active signing key %s not found in %s", activeKeyID, s.dir)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys = keys
+	s.active = active
+	return nil
+}
+
+func readPrivateKey(path string) (crypto.Signer, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(bytes)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var privateKey interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := privateKey.(type) {
+	case ed25519.PrivateKey:
+		return key, nil
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA keys must be at least %d bits", minRSAKeyBits)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, must be Ed25519 or RSA", privateKey)
+	}
+}
+
+// ActiveKey returns the key new tokens are signed with.
+func (s *KeySet) ActiveKey() SigningKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.active
+}
+
+// Key returns the key with the given kid.
+func (s *KeySet) Key(keyID string) (SigningKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[keyID]
+	return key, ok
+}
+
+// JWKS returns the public keys of the set ordered by kid.
+func (s *KeySet) JWKS() []JSONWebKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	jwks := make([]JSONWebKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		jwks = append(jwks, key.JWK())
+	}
+
+	sort.Slice(jwks, func(i, j int) bool {
+		return jwks[i].KeyID < jwks[j].KeyID
+	})
+
+	return jwks
+}
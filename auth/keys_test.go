@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeKey(t *testing.T, dir, keyID string, privateKey interface{}) {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("marshalling key: %v", err)
+	}
+
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	err = os.WriteFile(filepath.Join(dir, keyID+".pem"), pemBytes, 0o600)
+	if err != nil {
+		t.Fatalf("writing key: %v", err)
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	dir := t.TempDir()
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeKey(t, dir, "2022-11-01", edKey)
+
+	keys, err := LoadKeySet(dir, "")
+	if err != nil {
+		t.Fatalf("loading keys: %v", err)
+	}
+
+	issuer := NewTokenIssuer(keys)
+	oldToken, _, err := issuer.IssueAccessToken("user-id", "raider", nil)
+	if err != nil {
+		t.Fatalf("issuing token: %v", err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeKey(t, dir, "2022-12-01", rsaKey)
+
+	err = keys.Reload()
+	if err != nil {
+		t.Fatalf("reloading keys: %v", err)
+	}
+
+	if keys.ActiveKey().ID != "2022-12-01" || keys.ActiveKey().Algorithm() != "RS256" {
+		t.Errorf("got active key %s (%s), want 2022-12-01 (RS256)", keys.ActiveKey().ID, keys.ActiveKey().Algorithm())
+	}
+
+	jwks := keys.JWKS()
+	if len(jwks) != 2 || jwks[0].KeyType != "OKP" || jwks[1].KeyType != "RSA" || jwks[1].E != "AQAB" {
+		t.Errorf("unexpected JWKS %v", jwks)
+	}
+
+	newToken, _, err := issuer.IssueAccessToken("user-id", "raider", nil)
+	if err != nil {
+		t.Fatalf("issuing token: %v", err)
+	}
+
+	for _, token := range []string{oldToken, newToken} {
+		claims, err := issuer.ParseAccessToken(token)
+		if err != nil {
+			t.Fatalf("parsing token: %v", err)
+		}
+		if claims.Subject != "user-id" || claims.Login != "raider" {
+			t.Errorf("unexpected claims %v", claims)
+		}
+	}
+
+	pinned, err := LoadKeySet(dir, "2022-11-01")
+	if err != nil {
+		t.Fatalf("loading keys: %v", err)
+	}
+	if pinned.ActiveKey().ID != "2022-11-01" {
+		t.Errorf("got active key %s, want 2022-11-01", pinned.ActiveKey().ID)
+	}
+
+	_, err = LoadKeySet(dir, "unknown")
+	if err == nil {
+		t.Errorf("loading keys with unknown active key did not fail")
+	}
+}
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := NewRecoveryCodes(10)
+	if err != nil {
+		t.Fatalf("generating recovery codes: %v", err)
+	}
+
+	if len(codes) != 10 || len(hashes) != 10 {
+		t.Fatalf("got %d codes and %d hashes, want 10", len(codes), len(hashes))
+	}
+
+	seen := map[string]bool{}
+	for i, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("got code %s, want two groups of 5 characters", code)
+		}
+		if seen[code] {
+			t.Errorf("code %s was generated twice", code)
+		}
+		seen[code] = true
+
+		typed := strings.ToUpper(strings.Replace(code, "-", " ", 1))
+		if HashRecoveryCode(typed) != hashes[i] {
+			t.Errorf("hash of %q does not match the hash of %s", typed, code)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// SecretBoxKeyLength is the length of SecretBox keys in bytes.
+const SecretBoxKeyLength = 32
+
+var ErrSecretBoxOpen = errors.New("secret could not be decrypted")
+
+// SecretBox encrypts secrets stored at rest, like TOTP secrets, with AES-256-GCM.
+// Sealed secrets are bound to associated data, e.g. the user ID, so they can't be
+// copied to another user's record.
+type SecretBox struct {
+	aead cipher.AEAD
+}
+
+// NewSecretBox returns a SecretBox encrypting with a 32 byte key.
+func NewSecretBox(key []byte) (*SecretBox, error) {
+	if len(key) != SecretBoxKeyLength {
+		return nil, fmt.Errorf("secret box key must be %d bytes, got %d", SecretBoxKeyLength, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SecretBox{aead: aead}, nil
+}
+
+// NewEphemeralSecretBox returns a SecretBox with a generated key for local development.
+// Secrets sealed with it can't be opened after a restart.
+func NewEphemeralSecretBox() (*SecretBox, error) {
+	key := make([]byte, SecretBoxKeyLength)
+	_, err := rand.Read(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSecretBox(key)
+}
+
+// Seal encrypts plaintext and returns it base64 encoded along with its nonce.
+func (b *SecretBox) Seal(plaintext, associatedData []byte) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+
+	sealed := b.aead.Seal(nonce, nonce, plaintext, associatedData)
+	return base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a secret returned by Seal with the same associated data.
+func (b *SecretBox) Open(sealed string, associatedData []byte) ([]byte, error) {
+	bytes, err := base64.RawStdEncoding.DecodeString(sealed)
+	if err != nil || len(bytes) < b.aead.NonceSize() {
+		return nil, ErrSecretBoxOpen
+	}
+
+	nonce, ciphertext := bytes[:b.aead.NonceSize()], bytes[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, ciphertext, associatedData)
+	if err != nil {
+		return nil, ErrSecretBoxOpen
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ServerCertificates holds the TLS certificate the server presents and the CAs client
+// certificates are verified against, loaded from PEM files. Connections use the
+// certificates loaded last, so certificates are rotated by replacing the files and
+// reloading.
+type ServerCertificates struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+	modTimes    []time.Time
+}
+
+// LoadServerCertificates loads the certificate chain in certFile with its private key in
+// keyFile. Client certificates are verified against the CAs in clientCAFile, or not
+// requested when it is empty.
+func LoadServerCertificates(certFile, keyFile, clientCAFile string) (*ServerCertificates, error) {
+	certificates := &ServerCertificates{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+
+	err := certificates.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
+
+// Reload reads the certificates from disk again. The current certificates are kept if
+// loading fails.
+func (c *ServerCertificates) Reload() error {
+	modTimes, err := c.readModTimes()
+	if err != nil {
+		return err
+	}
+
+	certificate, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return fmt.Errorf("loading TLS certificate %s: %w", c.certFile, err)
+	}
+
+	var clientCAs *x509.CertPool
+	if c.clientCAFile != "" {
+		bytes, err := os.ReadFile(c.clientCAFile)
+		if err != nil {
+			return err
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(bytes) {
+			return fmt.Errorf("no CA certificates found in %s", c.clientCAFile)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.certificate = &certificate
+	c.clientCAs = clientCAs
+	c.modTimes = modTimes
+	return nil
+}
+
+// ReloadIfChanged reloads the certificates if any of their files was modified since they
+// were loaded, and returns whether it did.
+func (c *ServerCertificates) ReloadIfChanged() (bool, error) {
+	modTimes, err := c.readModTimes()
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.RLock()
+	changed := false
+	for i, modTime := range modTimes {
+		if !modTime.Equal(c.modTimes[i]) {
+			changed = true
+		}
+	}
+	c.mu.RUnlock()
+
+	if !changed {
+		return false, nil
+	}
+
+	return true, c.Reload()
+}
+
+func (c *ServerCertificates) readModTimes() ([]time.Time, error) {
+	var modTimes []time.Time
+	for _, path := range []string{c.certFile, c.keyFile, c.clientCAFile} {
+		if path == "" {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+
+	return modTimes, nil
+}
+
+// MutualTLS reports whether client certificates are requested and verified.
+func (c *ServerCertificates) MutualTLS() bool {
+	return c.clientCAFile != ""
+}
+
+// TLSConfig returns a gRPC server TLS config using the certificates loaded last for every
+// new connection. Client certificates are optional so users can still authenticate with
+// access tokens, but must be valid when presented.
+func (c *ServerCertificates) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c.mu.RLock()
+			defer c.mu.RUnlock()
+
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*c.certificate},
+				NextProtos:   []string{"h2"},
+			}
+			if c.clientCAs != nil {
+				config.ClientCAs = c.clientCAs
+				config.ClientAuth = tls.VerifyClientCertIfGiven
+			}
+			return config, nil
+		},
+	}
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCertificate writes a self-signed certificate for name and its key to dir, dated
+// modTime.
+func writeCertificate(t *testing.T, dir, name string, modTime time.Time) {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, publicKey, privateKey)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("marshalling key: %v", err)
+	}
+
+	files := map[string]*pem.Block{
+		"cert.pem": {Type: "CERTIFICATE", Bytes: der},
+		"key.pem":  {Type: "PRIVATE KEY", Bytes: keyDER},
+		"ca.pem":   {Type: "CERTIFICATE", Bytes: der},
+	}
+	for file, block := range files {
+		path := filepath.Join(dir, file)
+		err = os.WriteFile(path, pem.EncodeToMemory(block), 0o600)
+		if err != nil {
+			t.Fatalf("writing %s: %v", file, err)
+		}
+		err = os.Chtimes(path, modTime, modTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func servedCertificate(t *testing.T, certificates *ServerCertificates) *x509.Certificate {
+	t.Helper()
+
+	config, err := certificates.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatalf("getting config: %v", err)
+	}
+	if config.ClientAuth != tls.VerifyClientCertIfGiven || config.ClientCAs == nil {
+		t.Errorf("got client auth %v, want client certificates verified if given", config.ClientAuth)
+	}
+
+	cert, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestServerCertificatesReload(t *testing.T) {
+	dir := t.TempDir()
+	loadedAt := time.Now().Add(-time.Minute)
+	writeCertificate(t, dir, "users.raidcomp.internal", loadedAt)
+
+	certificates, err := LoadServerCertificates(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), filepath.Join(dir, "ca.pem"))
+	if err != nil {
+		t.Fatalf("loading certificates: %v", err)
+	}
+	if !certificates.MutualTLS() {
+		t.Errorf("got no mutual TLS with a client CA file")
+	}
+	if got := servedCertificate(t, certificates).Subject.CommonName; got != "users.raidcomp.internal" {
+		t.Errorf("got certificate %s, want users.raidcomp.internal", got)
+	}
+
+	reloaded, err := certificates.ReloadIfChanged()
+	if err != nil || reloaded {
+		t.Errorf("got reloaded %v (err: %v) without changes", reloaded, err)
+	}
+
+	// Rotated certificates are served once reloaded
+	writeCertificate(t, dir, "users-2.raidcomp.internal", time.Now())
+	reloaded, err = certificates.ReloadIfChanged()
+	if err != nil || !reloaded {
+		t.Fatalf("got reloaded %v (err: %v) after rotation", reloaded, err)
+	}
+	if got := servedCertificate(t, certificates).Subject.CommonName; got != "users-2.raidcomp.internal" {
+		t.Errorf("got certificate %s, want users-2.raidcomp.internal", got)
+	}
+
+	// Broken files keep the current certificates
+	err = os.WriteFile(filepath.Join(dir, "key.pem"), []byte("not a key"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = certificates.ReloadIfChanged()
+	if err == nil {
+		t.Errorf("got no error reloading a broken key")
+	}
+	if got := servedCertificate(t, certificates).Subject.CommonName; got != "users-2.raidcomp.internal" {
+		t.Errorf("got certificate %s after a failed reload, want users-2.raidcomp.internal", got)
+	}
+}
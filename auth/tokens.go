@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	TokenIssuerName        = "users-service"
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// AccessClaims are the claims carried by access tokens. The subject is the user ID.
+type AccessClaims struct {
+	Login string   `json:"login"`
+	Roles []string `json:"roles,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// TokenIssuer signs short-lived access tokens and mints opaque refresh tokens.
+type TokenIssuer struct {
+	Keys            *KeySet
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+func NewTokenIssuer(keys *KeySet) *TokenIssuer {
+	return &TokenIssuer{
+		Keys:            keys,
+		AccessTokenTTL:  DefaultAccessTokenTTL,
+		RefreshTokenTTL: DefaultRefreshTokenTTL,
+	}
+}
+
+func signingMethod(key SigningKey) jwt.SigningMethod {
+	if key.Algorithm() == "RS256" {
+		return jwt.SigningMethodRS256
+	}
+	return jwt.SigningMethodEdDSA
+}
+
+// IssueAccessToken returns a JWT for the user carrying their roles, signed with the active
+// key, and when it expires.
+func (i *TokenIssuer) IssueAccessToken(userID, login string, roles []string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(i.AccessTokenTTL)
+	claims := AccessClaims{
+		Login: login,
+		Roles: roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    TokenIssuerName,
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	key := i.Keys.ActiveKey()
+	token := jwt.NewWithClaims(signingMethod(key), claims)
+	token.Header["kid"] = key.ID
+
+	signed, err := token.SignedString(key.PrivateKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expiresAt, nil
+}
+
+// ParseAccessToken verifies the signature and expiry of an access token against the key
+// named by its kid and returns its claims.
+func (i *TokenIssuer) ParseAccessToken(token string) (*AccessClaims, error) {
+	claims := &AccessClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		keyID, _ := t.Header["kid"].(string)
+		key, ok := i.Keys.Key(keyID)
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", keyID)
+		}
+
+		// Never let the token pick the algorithm its key is used with
+		if t.Method != signingMethod(key) {
+			return nil, errors.New("unexpected signing method")
+		}
+
+		return key.PrivateKey.Public(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !claims.VerifyIssuer(TokenIssuerName, true) {
+		return nil, errors.New("unexpected token issuer")
+	}
+
+	return claims, nil
+}
+
+// NewRefreshToken returns a random opaque refresh token along with the hash to store for it.
+func (i *TokenIssuer) NewRefreshToken() (string, string, error) {
+	return NewOpaqueToken()
+}
+
+// NewOpaqueToken returns a random URL-safe token along with the hash to store for it.
+func NewOpaqueToken() (string, string, error) {
+	bytes := make([]byte, 32)
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(bytes)
+	return token, HashToken(token), nil
+}
+
+// HashToken returns the hex SHA-256 of an opaque token. Tokens are random so a fast
+// unsalted hash is enough to keep them unusable if the table is leaked.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// SHA1 test vectors from RFC 6238 appendix B, truncated to 6 digits
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 20000000000, want: "353130"},
+	}
+
+	for _, tt := range tests {
+		if got := TOTPCode(secret, TOTPStep(time.Unix(tt.unix, 0))); got != tt.want {
+			t.Errorf("code at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := NewTOTPSecret()
+	if err != nil {
+		t.Fatalf("generating secret: %v", err)
+	}
+
+	now := time.Now()
+	step := TOTPStep(now)
+	tests := []struct {
+		name string
+		step int64
+		want bool
+	}{
+		{name: "current step", step: step, want: true},
+		{name: "previous step", step: step - 1, want: true},
+		{name: "next step", step: step + 1, want: true},
+		{name: "too old", step: step - 2, want: false},
+		{name: "too new", step: step + 2, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, ok := ValidateTOTP(secret, TOTPCode(secret, tt.step), now)
+			if ok != tt.want {
+				t.Fatalf("got valid %v, want %v", ok, tt.want)
+			}
+			if ok && gotStep != tt.step {
+				t.Errorf("got step %d, want %d", gotStep, tt.step)
+			}
+		})
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("Raidcomp", "raider", []byte("12345678901234567890"))
+
+	if !strings.HasPrefix(uri, "otpauth://totp/Raidcomp:raider?") {
+		t.Errorf("got uri %s", uri)
+	}
+	if !strings.Contains(uri, "secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ") {
+		t.Errorf("uri %s does not contain the base32 secret", uri)
+	}
+}
+
+func TestSecretBox(t *testing.T) {
+	box, err := NewEphemeralSecretBox()
+	if err != nil {
+		t.Fatalf("creating secret box: %v", err)
+	}
+
+	sealed, err := box.Seal([]byte("secret"), []byte("user-1"))
+	if err != nil {
+		t.Fatalf("sealing: %v", err)
+	}
+
+	opened, err := box.Open(sealed, []byte("user-1"))
+	if err != nil || string(opened) != "secret" {
+		t.Errorf("got %q (err: %v), want the sealed secret", opened, err)
+	}
+
+	_, err = box.Open(sealed, []byte("user-2"))
+	if err != ErrSecretBoxOpen {
+		t.Errorf("opened secret with other associated data (err: %v)", err)
+	}
+}
@@ -5,6 +5,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 )
 
-func NewDynamoDBClient(cfg aws.Config) *dynamodb.Client {
-	return dynamodb.NewFromConfig(cfg)
+// NewDynamoDBClient returns a DynamoDB client, calling endpoint instead of the regional
+// endpoint when set, e.g. http://localhost:8000 for DynamoDB Local.
+func NewDynamoDBClient(cfg aws.Config, endpoint string) *dynamodb.Client {
+	return dynamodb.NewFromConfig(cfg, func(options *dynamodb.Options) {
+		if endpoint != "" {
+			options.EndpointResolver = dynamodb.EndpointResolverFromURL(endpoint)
+		}
+	})
 }
@@ -0,0 +1,53 @@
+package clients
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// VerificationEmail asks a user to verify their email address with Token.
+type VerificationEmail struct {
+	To        string
+	Login     string
+	Token     string
+	ExpiresAt time.Time
+}
+
+// PasswordResetEmail lets a user set a new password with Token.
+type PasswordResetEmail struct {
+	To        string
+	Login     string
+	Token     string
+	ExpiresAt time.Time
+}
+
+// Mailer sends the emails users receive from the service. Implementations own the
+// templates and the links the tokens are embedded in.
+type Mailer interface {
+	SendVerificationEmail(ctx context.Context, email VerificationEmail) error
+	SendPasswordResetEmail(ctx context.Context, email PasswordResetEmail) error
+}
+
+// logMailer writes emails to a logger instead of sending them, for local development.
+type logMailer struct {
+	logger *log.Logger
+}
+
+// NewLogMailer returns a Mailer writing every email to logger, e.g. one writing to
+// stderr or a file.
+func NewLogMailer(logger *log.Logger) Mailer {
+	return &logMailer{logger: logger}
+}
+
+func (m *logMailer) SendVerificationEmail(ctx context.Context, email VerificationEmail) error {
+	m.logger.Printf("Email to %s: verify the email of %s with token %s before %s",
+		email.To, email.Login, email.Token, email.ExpiresAt.Format(time.RFC3339))
+	return nil
+}
+
+func (m *logMailer) SendPasswordResetEmail(ctx context.Context, email PasswordResetEmail) error {
+	m.logger.Printf("Email to %s: reset the password of %s with token %s before %s",
+		email.To, email.Login, email.Token, email.ExpiresAt.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,52 @@
+// Command backfill brings the users stored in DynamoDB up to date with what the service
+// relies on, see daos.Backfill. It takes the same configuration as the server, and is run
+// before deploying and once more afterwards.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/raidcomp/users-service/clients"
+	"github.com/raidcomp/users-service/config"
+	"github.com/raidcomp/users-service/daos"
+	"log"
+	"os"
+)
+
+func main() {
+	cfg, err := config.Load(os.Args[0], os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	} else if err != nil {
+		log.Fatalf("unable to load config, %v", err)
+	}
+
+	if cfg.Storage != "dynamodb" {
+		log.Fatalf("storage %q has nothing to backfill", cfg.Storage)
+	}
+
+	var options []func(*awsconfig.LoadOptions) error
+	if cfg.DynamoDB.Region != "" {
+		options = append(options, awsconfig.WithRegion(cfg.DynamoDB.Region))
+	}
+	awsConfig, err := awsconfig.LoadDefaultConfig(context.Background(), options...)
+	if err != nil {
+		log.Fatalf("unable to load SDK config, %v", err)
+	}
+
+	dynamoDBClient := clients.NewDynamoDBClient(awsConfig, cfg.DynamoDB.Endpoint)
+	result, err := daos.Backfill(context.Background(), dynamoDBClient, cfg.DynamoDB.Tables())
+	log.Printf("Backfilled %d users", result.Users)
+	if err != nil {
+		log.Fatalf("unable to backfill users, %v", err)
+	}
+
+	for _, userID := range result.Conflicts {
+		log.Printf("userID %s has a login or email reserved by another user, change one of them and run again", userID)
+	}
+	if len(result.Conflicts) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,329 @@
+// Package config holds the configuration of the users service, loaded from flags,
+// environment variables and an optional YAML or TOML file, and validated before the
+// server starts.
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/raidcomp/users-service/auth"
+	"github.com/raidcomp/users-service/daos"
+	"github.com/raidcomp/users-service/policy"
+	"github.com/raidcomp/users-service/server"
+	"github.com/raidcomp/users-service/webauthn"
+	"golang.org/x/crypto/bcrypt"
+	"math"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Config is the configuration of the users service. Zero durations and lengths are
+// invalid rather than defaulted, start from Default to fill them.
+type Config struct {
+	// ListenAddress is the host:port the gRPC server listens on.
+	ListenAddress string `yaml:"listenAddress" toml:"listenAddress"`
+	// JWKSAddress is the host:port the JWKS is served on over HTTP, disabled when empty.
+	JWKSAddress string `yaml:"jwksAddress" toml:"jwksAddress"`
+	// Storage is dynamodb, or memory for local development.
+	Storage     string      `yaml:"storage" toml:"storage"`
+	DynamoDB    DynamoDB    `yaml:"dynamodb" toml:"dynamodb"`
+	SigningKeys SigningKeys `yaml:"signingKeys" toml:"signingKeys"`
+	Passwords   Passwords   `yaml:"passwords" toml:"passwords"`
+	Logins      Logins      `yaml:"logins" toml:"logins"`
+	Throttle    Throttle    `yaml:"throttle" toml:"throttle"`
+	WebAuthn    WebAuthn    `yaml:"webauthn" toml:"webauthn"`
+	TLS         TLS         `yaml:"tls" toml:"tls"`
+	Timeouts    Timeouts    `yaml:"timeouts" toml:"timeouts"`
+	// SecretsKey is the base64 encoded 32 byte key encrypting second factor secrets at
+	// rest. It is required with dynamodb storage, and generated on startup when empty
+	// with memory storage.
+	SecretsKey string `yaml:"secretsKey" toml:"secretsKey"`
+	// MailFile is the file emails are appended to. It is required with dynamodb storage,
+	// emails are logged when empty with memory storage.
+	MailFile          string `yaml:"mailFile" toml:"mailFile"`
+	SkipAuthorization bool   `yaml:"skipAuthorization" toml:"skipAuthorization"`
+}
+
+type DynamoDB struct {
+	// Endpoint replaces the regional endpoint, e.g. http://localhost:8000 for DynamoDB Local.
+	Endpoint string `yaml:"endpoint" toml:"endpoint"`
+	// Region defaults to the region of the AWS SDK configuration.
+	Region           string `yaml:"region" toml:"region"`
+	Table            string `yaml:"table" toml:"table"`
+	LoginIndex       string `yaml:"loginIndex" toml:"loginIndex"`
+	EmailIndex       string `yaml:"emailIndex" toml:"emailIndex"`
+	LoginPrefixIndex string `yaml:"loginPrefixIndex" toml:"loginPrefixIndex"`
+}
+
+type SigningKeys struct {
+	// Dir holds the <kid>.pem access token signing keys. It is required with dynamodb
+	// storage, and a key is generated on startup when empty with memory storage.
+	Dir string `yaml:"dir" toml:"dir"`
+	// ActiveKeyID defaults to the last kid in lexical order.
+	ActiveKeyID string `yaml:"activeKeyID" toml:"activeKeyID"`
+}
+
+type Passwords struct {
+	// Hash is the algorithm new passwords are hashed with: argon2id or bcrypt.
+	Hash                     string `yaml:"hash" toml:"hash"`
+	Argon2idMemory           uint   `yaml:"argon2idMemory" toml:"argon2idMemory"`
+	Argon2idIterations       uint   `yaml:"argon2idIterations" toml:"argon2idIterations"`
+	Argon2idParallelism      uint   `yaml:"argon2idParallelism" toml:"argon2idParallelism"`
+	BcryptCost               int    `yaml:"bcryptCost" toml:"bcryptCost"`
+	MinLength                int    `yaml:"minLength" toml:"minLength"`
+	MaxLength                int    `yaml:"maxLength" toml:"maxLength"`
+	BreachedPasswordsFile    string `yaml:"breachedPasswordsFile" toml:"breachedPasswordsFile"`
+	BreachedPasswordMinCount int    `yaml:"breachedPasswordMinCount" toml:"breachedPasswordMinCount"`
+}
+
+type Logins struct {
+	MinLength int `yaml:"minLength" toml:"minLength"`
+	MaxLength int `yaml:"maxLength" toml:"maxLength"`
+	// Reserved are reserved in addition to the default reserved logins.
+	Reserved []string `yaml:"reserved" toml:"reserved"`
+}
+
+type Throttle struct {
+	LockoutFailures   int           `yaml:"lockoutFailures" toml:"lockoutFailures"`
+	LockoutDelay      time.Duration `yaml:"lockoutDelay" toml:"lockoutDelay"`
+	LockoutMaxDelay   time.Duration `yaml:"lockoutMaxDelay" toml:"lockoutMaxDelay"`
+	AddressFailures   int           `yaml:"addressFailures" toml:"addressFailures"`
+	AddressDelay      time.Duration `yaml:"addressDelay" toml:"addressDelay"`
+	AddressMaxDelay   time.Duration `yaml:"addressMaxDelay" toml:"addressMaxDelay"`
+	TrustForwardedFor bool          `yaml:"trustForwardedFor" toml:"trustForwardedFor"`
+}
+
+type WebAuthn struct {
+	RPID   string `yaml:"rpID" toml:"rpID"`
+	RPName string `yaml:"rpName" toml:"rpName"`
+	// Origins default to https://<RPID>.
+	Origins []string `yaml:"origins" toml:"origins"`
+}
+
+type TLS struct {
+	// CertFile and KeyFile enable TLS, the server is plaintext when empty.
+	CertFile string `yaml:"certFile" toml:"certFile"`
+	KeyFile  string `yaml:"keyFile" toml:"keyFile"`
+	// ClientCAFile enables mutual TLS.
+	ClientCAFile   string        `yaml:"clientCAFile" toml:"clientCAFile"`
+	ReloadInterval time.Duration `yaml:"reloadInterval" toml:"reloadInterval"`
+	ServicesFile   string        `yaml:"servicesFile" toml:"servicesFile"`
+}
+
+type Timeouts struct {
+	// Request is the longest an RPC may take.
+	Request                time.Duration `yaml:"request" toml:"request"`
+	AccessToken            time.Duration `yaml:"accessToken" toml:"accessToken"`
+	RefreshToken           time.Duration `yaml:"refreshToken" toml:"refreshToken"`
+	EmailVerificationToken time.Duration `yaml:"emailVerificationToken" toml:"emailVerificationToken"`
+	PasswordResetToken     time.Duration `yaml:"passwordResetToken" toml:"passwordResetToken"`
+	SecondFactorToken      time.Duration `yaml:"secondFactorToken" toml:"secondFactorToken"`
+}
+
+// Default returns the configuration used for whatever isn't set, built from the defaults
+// of the packages configured.
+func Default() Config {
+	return Config{
+		ListenAddress: "localhost:5785",
+		Storage:       "dynamodb",
+		DynamoDB: DynamoDB{
+			Table:            daos.DefaultTables.Table,
+			LoginIndex:       daos.DefaultTables.LoginIndex,
+			EmailIndex:       daos.DefaultTables.EmailIndex,
+			LoginPrefixIndex: daos.DefaultTables.LoginPrefixIndex,
+		},
+		Passwords: Passwords{
+			Hash:                     "argon2id",
+			Argon2idMemory:           uint(auth.DefaultArgon2idParams.Memory),
+			Argon2idIterations:       uint(auth.DefaultArgon2idParams.Iterations),
+			Argon2idParallelism:      uint(auth.DefaultArgon2idParams.Parallelism),
+			BcryptCost:               bcrypt.DefaultCost,
+			MinLength:                policy.DefaultPasswordRules.MinLength,
+			MaxLength:                policy.DefaultPasswordRules.MaxLength,
+			BreachedPasswordMinCount: policy.DefaultPasswordRules.MinBreachCount,
+		},
+		Logins: Logins{
+			MinLength: policy.DefaultLoginRules.MinLength,
+			MaxLength: policy.DefaultLoginRules.MaxLength,
+		},
+		Throttle: Throttle{
+			LockoutFailures: server.DefaultAccountLockout.MaxFailures,
+			LockoutDelay:    server.DefaultAccountLockout.BaseDelay,
+			LockoutMaxDelay: server.DefaultAccountLockout.MaxDelay,
+			AddressFailures: server.DefaultAddressThrottle.MaxFailures,
+			AddressDelay:    server.DefaultAddressThrottle.BaseDelay,
+			AddressMaxDelay: server.DefaultAddressThrottle.MaxDelay,
+		},
+		WebAuthn: WebAuthn{
+			RPID:   "localhost",
+			RPName: "Raidcomp",
+		},
+		TLS: TLS{
+			ReloadInterval: time.Minute,
+		},
+		Timeouts: Timeouts{
+			Request:                10 * time.Second,
+			AccessToken:            auth.DefaultAccessTokenTTL,
+			RefreshToken:           auth.DefaultRefreshTokenTTL,
+			EmailVerificationToken: server.DefaultTokenTTLs.EmailVerification,
+			PasswordResetToken:     server.DefaultTokenTTLs.PasswordReset,
+			SecondFactorToken:      server.DefaultTokenTTLs.SecondFactor,
+		},
+	}
+}
+
+// Validate checks the whole configuration, returning every problem found at once.
+func (c Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+	checkAddress := func(name, address string) {
+		_, _, err := net.SplitHostPort(address)
+		check(err == nil, "%s %q must be a host:port", name, address)
+	}
+	checkPositive := func(name string, duration time.Duration) {
+		check(duration > 0, "%s must be positive", name)
+	}
+
+	checkAddress("listenAddress", c.ListenAddress)
+	if c.JWKSAddress != "" {
+		checkAddress("jwksAddress", c.JWKSAddress)
+	}
+
+	check(c.Storage == "dynamodb" || c.Storage == "memory", "storage %q must be dynamodb or memory", c.Storage)
+	if c.DynamoDB.Endpoint != "" {
+		endpoint, err := url.Parse(c.DynamoDB.Endpoint)
+		check(err == nil && (endpoint.Scheme == "http" || endpoint.Scheme == "https") && endpoint.Host != "",
+			"dynamodb.endpoint %q must be an http or https URL", c.DynamoDB.Endpoint)
+	}
+	check(c.DynamoDB.Table != "", "dynamodb.table is required")
+	check(c.DynamoDB.LoginIndex != "", "dynamodb.loginIndex is required")
+	check(c.DynamoDB.EmailIndex != "", "dynamodb.emailIndex is required")
+	check(c.DynamoDB.LoginPrefixIndex != "", "dynamodb.loginPrefixIndex is required")
+
+	check(c.SigningKeys.ActiveKeyID == "" || c.SigningKeys.Dir != "", "signingKeys.activeKeyID requires signingKeys.dir")
+	if c.Storage == "dynamodb" {
+		// Generated keys would be lost on restart, along with the secrets and tokens they protect
+		check(c.SigningKeys.Dir != "", "signingKeys.dir is required with dynamodb storage")
+		check(c.SecretsKey != "", "secretsKey is required with dynamodb storage")
+		// Logged emails would leak their tokens to whoever reads the logs
+		check(c.MailFile != "", "mailFile is required with dynamodb storage")
+	}
+	if c.SecretsKey != "" {
+		key, err := base64.StdEncoding.DecodeString(c.SecretsKey)
+		check(err == nil && len(key) == auth.SecretBoxKeyLength, "secretsKey must be a base64 encoded %d byte key", auth.SecretBoxKeyLength)
+	}
+
+	passwords := c.Passwords
+	check(passwords.Hash == "argon2id" || passwords.Hash == "bcrypt", "passwords.hash %q must be argon2id or bcrypt", passwords.Hash)
+	check(passwords.Argon2idMemory > 0 && passwords.Argon2idMemory <= math.MaxUint32, "passwords.argon2idMemory must be between 1 and %d KiB", uint64(math.MaxUint32))
+	check(passwords.Argon2idIterations > 0 && passwords.Argon2idIterations <= math.MaxUint32, "passwords.argon2idIterations must be between 1 and %d", uint64(math.MaxUint32))
+	check(passwords.Argon2idParallelism > 0 && passwords.Argon2idParallelism <= math.MaxUint8, "passwords.argon2idParallelism must be between 1 and %d", math.MaxUint8)
+	check(passwords.Argon2idMemory >= 8*passwords.Argon2idParallelism, "passwords.argon2idMemory must be at least 8 KiB per thread of parallelism")
+	check(passwords.BcryptCost >= bcrypt.MinCost && passwords.BcryptCost <= bcrypt.MaxCost, "passwords.bcryptCost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	check(passwords.MinLength > 0 && passwords.MinLength <= passwords.MaxLength, "passwords.minLength must be positive and at most passwords.maxLength")
+	check(passwords.BreachedPasswordMinCount > 0, "passwords.breachedPasswordMinCount must be positive")
+
+	check(c.Logins.MinLength > 0 && c.Logins.MinLength <= c.Logins.MaxLength, "logins.minLength must be positive and at most logins.maxLength")
+
+	throttle := c.Throttle
+	check(throttle.LockoutFailures > 0, "throttle.lockoutFailures must be positive")
+	checkPositive("throttle.lockoutDelay", throttle.LockoutDelay)
+	check(throttle.LockoutMaxDelay >= throttle.LockoutDelay, "throttle.lockoutMaxDelay must be at least throttle.lockoutDelay")
+	check(throttle.AddressFailures > 0, "throttle.addressFailures must be positive")
+	checkPositive("throttle.addressDelay", throttle.AddressDelay)
+	check(throttle.AddressMaxDelay >= throttle.AddressDelay, "throttle.addressMaxDelay must be at least throttle.addressDelay")
+
+	check(c.WebAuthn.RPID != "", "webauthn.rpID is required")
+	for _, origin := range c.WebAuthn.Origins {
+		originURL, err := url.Parse(origin)
+		check(err == nil && originURL.Scheme != "" && originURL.Host != "", "webauthn origin %q must be a URL", origin)
+	}
+
+	check((c.TLS.CertFile == "") == (c.TLS.KeyFile == ""), "tls.certFile and tls.keyFile must be set together")
+	check(c.TLS.ClientCAFile == "" || c.TLS.CertFile != "", "tls.clientCAFile requires tls.certFile")
+	check(c.TLS.ClientCAFile == "" || c.TLS.ServicesFile != "", "tls.clientCAFile requires tls.servicesFile")
+	checkPositive("tls.reloadInterval", c.TLS.ReloadInterval)
+
+	checkPositive("timeouts.request", c.Timeouts.Request)
+	checkPositive("timeouts.accessToken", c.Timeouts.AccessToken)
+	checkPositive("timeouts.refreshToken", c.Timeouts.RefreshToken)
+	checkPositive("timeouts.emailVerificationToken", c.Timeouts.EmailVerificationToken)
+	checkPositive("timeouts.passwordResetToken", c.Timeouts.PasswordResetToken)
+	checkPositive("timeouts.secondFactorToken", c.Timeouts.SecondFactorToken)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Tables returns the table and indexes the DAOs use.
+func (d DynamoDB) Tables() daos.Tables {
+	return daos.Tables{
+		Table:            d.Table,
+		LoginIndex:       d.LoginIndex,
+		EmailIndex:       d.EmailIndex,
+		LoginPrefixIndex: d.LoginPrefixIndex,
+	}
+}
+
+// Argon2idParams returns the argon2id parameters, keeping the default salt and key lengths.
+func (p Passwords) Argon2idParams() auth.Argon2idParams {
+	params := auth.DefaultArgon2idParams
+	params.Memory = uint32(p.Argon2idMemory)
+	params.Iterations = uint32(p.Argon2idIterations)
+	params.Parallelism = uint8(p.Argon2idParallelism)
+	return params
+}
+
+// Rules returns the default password rules with the configured lengths. Breached
+// passwords are only checked once the caller opens BreachedPasswordsFile.
+func (p Passwords) Rules() policy.PasswordRules {
+	rules := policy.DefaultPasswordRules
+	rules.MinLength = p.MinLength
+	rules.MaxLength = p.MaxLength
+	rules.MinBreachCount = p.BreachedPasswordMinCount
+	return rules
+}
+
+// Rules returns the default login rules with the configured lengths and reserved logins.
+func (l Logins) Rules() policy.LoginRules {
+	rules := policy.DefaultLoginRules
+	rules.MinLength = l.MinLength
+	rules.MaxLength = l.MaxLength
+	rules.ReservedWords = append(append([]string{}, rules.ReservedWords...), l.Reserved...)
+	return rules
+}
+
+func (t Throttle) AccountLockout() server.LockoutPolicy {
+	return server.LockoutPolicy{MaxFailures: t.LockoutFailures, BaseDelay: t.LockoutDelay, MaxDelay: t.LockoutMaxDelay}
+}
+
+func (t Throttle) AddressThrottle() server.LockoutPolicy {
+	return server.LockoutPolicy{MaxFailures: t.AddressFailures, BaseDelay: t.AddressDelay, MaxDelay: t.AddressMaxDelay}
+}
+
+// RelyingParty returns the WebAuthn relying party, allowing https://<RPID> when no
+// origins are set.
+func (w WebAuthn) RelyingParty() *webauthn.RelyingParty {
+	origins := w.Origins
+	if len(origins) == 0 {
+		origins = []string{"https://" + w.RPID}
+	}
+	return &webauthn.RelyingParty{ID: w.RPID, Name: w.RPName, Origins: origins}
+}
+
+// TokenTTLs returns the lifetimes of the single-use tokens issued by the server.
+func (t Timeouts) TokenTTLs() server.TokenTTLs {
+	return server.TokenTTLs{
+		EmailVerification: t.EmailVerificationToken,
+		PasswordReset:     t.PasswordResetToken,
+		SecondFactor:      t.SecondFactorToken,
+	}
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+// testSecretsKey is a base64 encoded 32 byte key.
+const testSecretsKey = "MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDE="
+
+// validConfig returns the defaults with the keys dynamodb storage requires.
+func validConfig() Config {
+	c := Default()
+	c.SigningKeys.Dir = "keys"
+	c.SecretsKey = testSecretsKey
+	c.MailFile = "mail.log"
+	return c
+}
+
+func TestDefaultIsValid(t *testing.T) {
+	err := validConfig().Validate()
+	if err != nil {
+		t.Errorf("got %v validating the defaults", err)
+	}
+
+	// Keys are generated for memory storage
+	c := Default()
+	c.Storage = "memory"
+	err = c.Validate()
+	if err != nil {
+		t.Errorf("got %v validating the defaults with memory storage", err)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, "users.yaml", `
+listenAddress: 0.0.0.0:5785
+storage: memory
+dynamodb:
+  table: users-file
+  endpoint: http://localhost:8000
+passwords:
+  bcryptCost: 12
+timeouts:
+  request: 5s
+`)
+
+	c, err := Load("users", []string{"-config", path, "-dynamodb-table", "users-flag", "-reserved-logins", "raidleader,officer"}, env(map[string]string{
+		"USERS_DYNAMODB_TABLE": "users-env",
+		"USERS_BCRYPT_COST":    "11",
+		"USERS_LISTEN_ADDRESS": "0.0.0.0:6000",
+	}))
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+
+	if c.DynamoDB.Table != "users-flag" {
+		t.Errorf("got table %s, want the flag over the environment and file", c.DynamoDB.Table)
+	}
+	if c.Passwords.BcryptCost != 11 || c.ListenAddress != "0.0.0.0:6000" {
+		t.Errorf("got bcrypt cost %d and listen address %s, want the environment over the file", c.Passwords.BcryptCost, c.ListenAddress)
+	}
+	if c.Storage != "memory" || c.DynamoDB.Endpoint != "http://localhost:8000" || c.Timeouts.Request != 5*time.Second {
+		t.Errorf("got storage %s, endpoint %s and request timeout %s, want the file over the defaults", c.Storage, c.DynamoDB.Endpoint, c.Timeouts.Request)
+	}
+	if c.DynamoDB.LoginIndex != Default().DynamoDB.LoginIndex || c.Timeouts.AccessToken != Default().Timeouts.AccessToken {
+		t.Errorf("got login index %s and access token TTL %s, want the defaults", c.DynamoDB.LoginIndex, c.Timeouts.AccessToken)
+	}
+	if strings.Join(c.Logins.Reserved, ",") != "raidleader,officer" {
+		t.Errorf("got reserved logins %v", c.Logins.Reserved)
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	path := writeFile(t, "users.toml", `
+listenAddress = "0.0.0.0:5785"
+
+secretsKey = "MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDE="
+mailFile = "mail.log"
+
+[dynamodb]
+table = "users-toml"
+
+[signingKeys]
+dir = "keys"
+
+[webauthn]
+rpID = "raidcomp.io"
+origins = ["https://raidcomp.io", "https://app.raidcomp.io"]
+
+[timeouts]
+secondFactorToken = "2m"
+`)
+
+	c, err := Load("users", nil, env(map[string]string{"USERS_CONFIG": path}))
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+
+	if c.DynamoDB.Table != "users-toml" || c.Timeouts.SecondFactorToken != 2*time.Minute || len(c.WebAuthn.Origins) != 2 {
+		t.Errorf("got table %s, second factor token TTL %s and origins %v", c.DynamoDB.Table, c.Timeouts.SecondFactorToken, c.WebAuthn.Origins)
+	}
+	if rp := c.WebAuthn.RelyingParty(); rp.ID != "raidcomp.io" || len(rp.Origins) != 2 {
+		t.Errorf("got relying party %+v", rp)
+	}
+}
+
+func TestLoadRejectsUnknownKeys(t *testing.T) {
+	for _, file := range []struct{ name, content string }{
+		{name: "users.yaml", content: "dynamodb:\n  tabel: users\n"},
+		{name: "users.toml", content: "[dynamodb]\ntabel = \"users\"\n"},
+		{name: "users.json", content: "{}"},
+	} {
+		t.Run(file.name, func(t *testing.T) {
+			_, err := Load("users", []string{"-config", writeFile(t, file.name, file.content)}, env(nil))
+			if err == nil {
+				t.Errorf("got no error")
+			}
+		})
+	}
+}
+
+func TestLoadRejectsInvalidEnvironment(t *testing.T) {
+	_, err := Load("users", nil, env(map[string]string{"USERS_REQUEST_TIMEOUT": "soon"}))
+	if err == nil || !strings.Contains(err.Error(), "USERS_REQUEST_TIMEOUT") {
+		t.Errorf("got %v, want an error naming USERS_REQUEST_TIMEOUT", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   string
+	}{
+		{name: "listen address", modify: func(c *Config) { c.ListenAddress = "5785" }, want: "listenAddress"},
+		{name: "storage", modify: func(c *Config) { c.Storage = "postgres" }, want: "storage"},
+		{name: "endpoint", modify: func(c *Config) { c.DynamoDB.Endpoint = "localhost:8000" }, want: "dynamodb.endpoint"},
+		{name: "table", modify: func(c *Config) { c.DynamoDB.Table = "" }, want: "dynamodb.table"},
+		{name: "hash", modify: func(c *Config) { c.Passwords.Hash = "md5" }, want: "passwords.hash"},
+		{name: "bcrypt cost", modify: func(c *Config) { c.Passwords.BcryptCost = 40 }, want: "passwords.bcryptCost"},
+		{name: "argon2id parallelism", modify: func(c *Config) { c.Passwords.Argon2idParallelism = 256 }, want: "passwords.argon2idParallelism"},
+		{name: "login lengths", modify: func(c *Config) { c.Logins.MinLength = 30 }, want: "logins.minLength"},
+		{name: "lockout delays", modify: func(c *Config) { c.Throttle.LockoutMaxDelay = time.Second }, want: "throttle.lockoutMaxDelay"},
+		{name: "TLS key", modify: func(c *Config) { c.TLS.CertFile = "cert.pem" }, want: "tls.keyFile"},
+		{name: "client CA", modify: func(c *Config) { c.TLS.ClientCAFile = "ca.pem" }, want: "tls.clientCAFile requires tls.certFile"},
+		{name: "client CA without services", modify: func(c *Config) {
+			c.TLS.CertFile, c.TLS.KeyFile, c.TLS.ClientCAFile = "cert.pem", "key.pem", "ca.pem"
+		}, want: "tls.clientCAFile requires tls.servicesFile"},
+		{name: "request timeout", modify: func(c *Config) { c.Timeouts.Request = 0 }, want: "timeouts.request"},
+		{name: "no signing keys", modify: func(c *Config) { c.SigningKeys.Dir = "" }, want: "signingKeys.dir is required"},
+		{name: "no secrets key", modify: func(c *Config) { c.SecretsKey = "" }, want: "secretsKey is required"},
+		{name: "no mail file", modify: func(c *Config) { c.MailFile = "" }, want: "mailFile is required"},
+		{name: "secrets key encoding", modify: func(c *Config) { c.SecretsKey = "not base64!" }, want: "secretsKey must be"},
+		{name: "secrets key length", modify: func(c *Config) { c.SecretsKey = "MDEyMzQ1Njc4OTAxMjM0NQ==" }, want: "secretsKey must be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validConfig()
+			tt.modify(&c)
+			err := c.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want an error about %s", err, tt.want)
+			}
+		})
+	}
+
+	// Every problem is reported at once
+	c := Default()
+	c.Storage = ""
+	c.Timeouts.Request = 0
+	err := c.Validate()
+	if err == nil || !strings.Contains(err.Error(), "storage") || !strings.Contains(err.Error(), "timeouts.request") {
+		t.Errorf("got %v, want both problems", err)
+	}
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// envPrefix prefixes the environment variable of every flag, e.g. USERS_LISTEN_ADDRESS
+// for -listen-address.
+const envPrefix = "USERS_"
+
+// stringList is a flag of comma-separated values.
+type stringList struct {
+	values *[]string
+}
+
+func (l stringList) String() string {
+	if l.values == nil {
+		return ""
+	}
+	return strings.Join(*l.values, ",")
+}
+
+func (l stringList) Set(value string) error {
+	*l.values = nil
+	if value != "" {
+		*l.values = strings.Split(value, ",")
+	}
+	return nil
+}
+
+// bindFlags defines a flag for every setting of c, defaulting to its current value.
+func bindFlags(flags *flag.FlagSet, c *Config) {
+	flags.StringVar(&c.ListenAddress, "listen-address", c.ListenAddress, "host:port to serve gRPC on")
+	flags.StringVar(&c.JWKSAddress, "jwks-address", c.JWKSAddress, "host:port to serve /.well-known/jwks.json over HTTP on, disabled when empty")
+	flags.StringVar(&c.Storage, "storage", c.Storage, "where users are stored: dynamodb, or memory for local development")
+
+	flags.StringVar(&c.DynamoDB.Endpoint, "dynamodb-endpoint", c.DynamoDB.Endpoint, "DynamoDB endpoint URL replacing the regional endpoint, e.g. for DynamoDB Local")
+	flags.StringVar(&c.DynamoDB.Region, "dynamodb-region", c.DynamoDB.Region, "AWS region of the DynamoDB table, defaults to the AWS SDK configuration")
+	flags.StringVar(&c.DynamoDB.Table, "dynamodb-table", c.DynamoDB.Table, "DynamoDB table users are stored in")
+	flags.StringVar(&c.DynamoDB.LoginIndex, "dynamodb-login-index", c.DynamoDB.LoginIndex, "index of users by canonical login")
+	flags.StringVar(&c.DynamoDB.EmailIndex, "dynamodb-email-index", c.DynamoDB.EmailIndex, "index of users by canonical email")
+	flags.StringVar(&c.DynamoDB.LoginPrefixIndex, "dynamodb-login-prefix-index", c.DynamoDB.LoginPrefixIndex, "index of users by login search shard")
+
+	flags.StringVar(&c.SigningKeys.Dir, "signing-keys-dir", c.SigningKeys.Dir, "directory of <kid>.pem Ed25519 or RSA private keys to sign access tokens with")
+	flags.StringVar(&c.SigningKeys.ActiveKeyID, "signing-key-id", c.SigningKeys.ActiveKeyID, "kid of the key to sign new access tokens with, defaults to the last kid in lexical order")
+
+	flags.StringVar(&c.Passwords.Hash, "password-hash", c.Passwords.Hash, "algorithm to hash new passwords with: argon2id or bcrypt")
+	flags.UintVar(&c.Passwords.Argon2idMemory, "argon2id-memory", c.Passwords.Argon2idMemory, "argon2id memory cost in KiB")
+	flags.UintVar(&c.Passwords.Argon2idIterations, "argon2id-iterations", c.Passwords.Argon2idIterations, "argon2id time cost")
+	flags.UintVar(&c.Passwords.Argon2idParallelism, "argon2id-parallelism", c.Passwords.Argon2idParallelism, "argon2id parallelism")
+	flags.IntVar(&c.Passwords.BcryptCost, "bcrypt-cost", c.Passwords.BcryptCost, "bcrypt cost")
+	flags.IntVar(&c.Passwords.MinLength, "password-min-length", c.Passwords.MinLength, "minimum password length")
+	flags.IntVar(&c.Passwords.MaxLength, "password-max-length", c.Passwords.MaxLength, "maximum password length")
+	flags.StringVar(&c.Passwords.BreachedPasswordsFile, "breached-passwords-file", c.Passwords.BreachedPasswordsFile, "Have I Been Pwned style file of breached password SHA-1 hashes to reject, sorted by hash")
+	flags.IntVar(&c.Passwords.BreachedPasswordMinCount, "breached-password-min-count", c.Passwords.BreachedPasswordMinCount, "times a password must have been seen in breaches to be rejected")
+
+	flags.IntVar(&c.Logins.MinLength, "login-min-length", c.Logins.MinLength, "minimum login length")
+	flags.IntVar(&c.Logins.MaxLength, "login-max-length", c.Logins.MaxLength, "maximum login length")
+	flags.Var(stringList{&c.Logins.Reserved}, "reserved-logins", "comma-separated logins reserved in addition to the defaults")
+
+	flags.IntVar(&c.Throttle.LockoutFailures, "lockout-failures", c.Throttle.LockoutFailures, "failed password checks before an account is temporarily locked")
+	flags.DurationVar(&c.Throttle.LockoutDelay, "lockout-delay", c.Throttle.LockoutDelay, "how long accounts are first locked for, doubling with every further failure")
+	flags.DurationVar(&c.Throttle.LockoutMaxDelay, "lockout-max-delay", c.Throttle.LockoutMaxDelay, "longest an account is locked for")
+	flags.IntVar(&c.Throttle.AddressFailures, "address-throttle-failures", c.Throttle.AddressFailures, "failed password checks from one address before it is temporarily throttled")
+	flags.DurationVar(&c.Throttle.AddressDelay, "address-throttle-delay", c.Throttle.AddressDelay, "how long addresses are first throttled for, doubling with every further failure")
+	flags.DurationVar(&c.Throttle.AddressMaxDelay, "address-throttle-max-delay", c.Throttle.AddressMaxDelay, "longest an address is throttled for")
+	flags.BoolVar(&c.Throttle.TrustForwardedFor, "trust-forwarded-for", c.Throttle.TrustForwardedFor, "throttle by the x-forwarded-for address, only enable behind a proxy setting it")
+
+	flags.StringVar(&c.WebAuthn.RPID, "webauthn-rp-id", c.WebAuthn.RPID, "domain passkeys are registered for")
+	flags.StringVar(&c.WebAuthn.RPName, "webauthn-rp-name", c.WebAuthn.RPName, "name shown to users registering a passkey")
+	flags.Var(stringList{&c.WebAuthn.Origins}, "webauthn-origins", "comma-separated origins passkeys may be used from, defaults to https://<webauthn-rp-id>")
+
+	flags.StringVar(&c.TLS.CertFile, "tls-cert-file", c.TLS.CertFile, "PEM certificate chain to serve TLS with, plaintext when empty")
+	flags.StringVar(&c.TLS.KeyFile, "tls-key-file", c.TLS.KeyFile, "PEM private key of the TLS certificate")
+	flags.StringVar(&c.TLS.ClientCAFile, "tls-client-ca-file", c.TLS.ClientCAFile, "PEM CA certificates to verify service client certificates with, enabling mutual TLS")
+	flags.DurationVar(&c.TLS.ReloadInterval, "tls-reload-interval", c.TLS.ReloadInterval, "how often TLS certificate files are checked for changes to reload them")
+	flags.StringVar(&c.TLS.ServicesFile, "services-file", c.TLS.ServicesFile, "JSON file of the services allowed to call the server, by client certificate SAN, and the methods each may call")
+
+	flags.DurationVar(&c.Timeouts.Request, "request-timeout", c.Timeouts.Request, "longest an RPC may take")
+	flags.DurationVar(&c.Timeouts.AccessToken, "access-token-ttl", c.Timeouts.AccessToken, "how long access tokens are valid")
+	flags.DurationVar(&c.Timeouts.RefreshToken, "refresh-token-ttl", c.Timeouts.RefreshToken, "how long refresh tokens are valid")
+	flags.DurationVar(&c.Timeouts.EmailVerificationToken, "email-verification-token-ttl", c.Timeouts.EmailVerificationToken, "how long email verification tokens are valid")
+	flags.DurationVar(&c.Timeouts.PasswordResetToken, "password-reset-token-ttl", c.Timeouts.PasswordResetToken, "how long password reset tokens are valid")
+	flags.DurationVar(&c.Timeouts.SecondFactorToken, "second-factor-token-ttl", c.Timeouts.SecondFactorToken, "how long users have to enter their second factor after their password")
+
+	flags.StringVar(&c.SecretsKey, "secrets-key", c.SecretsKey, "base64 encoded 32 byte key encrypting second factor secrets at rest")
+	flags.StringVar(&c.MailFile, "mail-file", c.MailFile, "file to append emails to, emails are logged when unset with memory storage")
+	flags.BoolVar(&c.SkipAuthorization, "skip-authorization", c.SkipAuthorization, "let any caller call any RPC, for local development and granting the first admin role")
+}
+
+// envName returns the environment variable of a flag.
+func envName(flagName string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// Load returns the configuration read from args, the environment variables looked up
+// with lookupEnv, and the file set by -config or USERS_CONFIG. Flags take precedence over
+// environment variables, which take precedence over the file, which takes precedence over
+// the defaults. The configuration is validated.
+func Load(name string, args []string, lookupEnv func(string) (string, bool)) (Config, error) {
+	c := Default()
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	path := flags.String("config", "", "YAML (.yaml, .yml) or TOML (.toml) config file, overridden by environment variables and flags (env "+envPrefix+"CONFIG)")
+	bindFlags(flags, &c)
+	flags.VisitAll(func(f *flag.Flag) {
+		if f.Name != "config" {
+			f.Usage += " (env " + envName(f.Name) + ")"
+		}
+	})
+
+	err := flags.Parse(args)
+	if err != nil {
+		return Config{}, err
+	}
+	if flags.NArg() > 0 {
+		return Config{}, fmt.Errorf("unexpected arguments %v", flags.Args())
+	}
+
+	// Parsing set the flags on c, keep them to apply them again last
+	set := map[string]string{}
+	flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = f.Value.String()
+	})
+
+	if *path == "" {
+		*path, _ = lookupEnv(envPrefix + "CONFIG")
+	}
+	c = Default()
+	if *path != "" {
+		err = decodeFile(*path, &c)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	var envErr error
+	flags.VisitAll(func(f *flag.Flag) {
+		value, ok := lookupEnv(envName(f.Name))
+		if !ok || f.Name == "config" || envErr != nil {
+			return
+		}
+		err := f.Value.Set(value)
+		if err != nil {
+			envErr = fmt.Errorf("invalid value %q for %s: %w", value, envName(f.Name), err)
+		}
+	})
+	if envErr != nil {
+		return Config{}, envErr
+	}
+
+	for flagName, value := range set {
+		err = flags.Set(flagName, value)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	return c, c.Validate()
+}
+
+// decodeFile decodes a YAML or TOML file, by its extension, into c. Unknown keys are
+// rejected so typos don't go unnoticed.
+func decodeFile(path string, c *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		err = decoder.Decode(c)
+		if errors.Is(err, io.EOF) {
+			// The file is empty
+			err = nil
+		}
+	case ".toml":
+		var metadata toml.MetaData
+		metadata, err = toml.Decode(string(content), c)
+		if err == nil && len(metadata.Undecoded()) > 0 {
+			err = fmt.Errorf("unknown keys %v", metadata.Undecoded())
+		}
+	default:
+		return fmt.Errorf("config file %s must be .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	return nil
+}
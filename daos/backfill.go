@@ -0,0 +1,181 @@
+package daos
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Users written by earlier versions lack the items and attributes later versions rely on,
+// such as reservations and canonical forms. Backfill writes them, from a separate command
+// since it scans the whole table.
+
+// BackfillResult counts the users a Backfill went through.
+type BackfillResult struct {
+	Users int
+	// Conflicts are the IDs of users whose login or email is already reserved by another
+	// user, which must be resolved by hand, e.g. by changing one of them.
+	Conflicts []string
+}
+
+// Backfill brings every user item in tables up to date: it writes the missing login and
+// email reservations, canonicalLogin, canonicalEmail and loginSearchShard, and rewrites
+// createdAt in its sortable form. It can safely be run again, so it is run before
+// deploying a version relying on these, and once more afterwards for the users the
+// previous version created meanwhile.
+func Backfill(ctx context.Context, dynamoDBClient *dynamodb.Client, tables Tables) (BackfillResult, error) {
+	dao := usersDAOImpl{DynamoDBClient: dynamoDBClient, tableName: tables.Table}
+
+	// Reservations, sessions and other items stored next to the users have no login
+	expr, err := expression.NewBuilder().WithFilter(expression.AttributeExists(expression.Name("login"))).Build()
+	if err != nil {
+		return BackfillResult{}, err
+	}
+
+	var (
+		result            BackfillResult
+		exclusiveStartKey map[string]types.AttributeValue
+	)
+	for {
+		scanOutput, err := dao.DynamoDBClient.Scan(ctx, &dynamodb.ScanInput{
+			TableName:                aws.String(dao.tableName),
+			FilterExpression:         expr.Filter(),
+			ExpressionAttributeNames: expr.Names(),
+			ExclusiveStartKey:        exclusiveStartKey,
+			ConsistentRead:           aws.Bool(true),
+		})
+		if err != nil {
+			return result, dynamoDBError(err)
+		}
+
+		for _, item := range scanOutput.Items {
+			var user User
+			err = attributevalue.UnmarshalMap(item, &user)
+			if err != nil {
+				return result, corruptRecordError(err)
+			}
+			result.Users++
+
+			err = dao.backfillUser(ctx, user, item)
+			if errors.Is(err, ErrLoginTaken) || errors.Is(err, ErrEmailTaken) {
+				result.Conflicts = append(result.Conflicts, user.UserID)
+			} else if err != nil {
+				return result, err
+			}
+		}
+
+		exclusiveStartKey = scanOutput.LastEvaluatedKey
+		if exclusiveStartKey == nil {
+			return result, nil
+		}
+	}
+}
+
+// backfillUser writes what user, stored as item, is missing. It returns ErrLoginTaken or
+// ErrEmailTaken if another user holds the reservation.
+func (dao usersDAOImpl) backfillUser(ctx context.Context, user User, item map[string]types.AttributeValue) error {
+	canonicalLogin := CanonicalLogin(user.Login)
+	canonicalEmail := CanonicalEmail(user.Email)
+
+	var (
+		updateExpr expression.UpdateBuilder
+		update     bool
+	)
+	set := func(name, value string) {
+		if stored, ok := item[name].(*types.AttributeValueMemberS); !ok || stored.Value != value {
+			updateExpr = updateExpr.Set(expression.Name(name), expression.Value(value))
+			update = true
+		}
+	}
+	set("canonicalLogin", canonicalLogin)
+	set("canonicalEmail", canonicalEmail)
+	set("loginSearchShard", loginSearchShard(canonicalLogin))
+	set("createdAt", sortableTime(user.CreatedAt))
+
+	loginReservation, err := dao.backfillReservation(loginReservationKey(canonicalLogin), user.UserID)
+	if err != nil {
+		return err
+	}
+	emailReservation, err := dao.backfillReservation(emailReservationKey(canonicalEmail), user.UserID)
+	if err != nil {
+		return err
+	}
+
+	// The user must not change meanwhile, or the reservations could be stale
+	cond := expression.Name("login").Equal(expression.Value(user.Login)).
+		And(expression.Name("email").Equal(expression.Value(user.Email)))
+	builder := expression.NewBuilder().WithCondition(cond)
+	if update {
+		builder = builder.WithUpdate(updateExpr)
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return err
+	}
+
+	key := map[string]types.AttributeValue{
+		"userID": &types.AttributeValueMemberS{Value: user.UserID},
+	}
+	userItem := types.TransactWriteItem{
+		ConditionCheck: &types.ConditionCheck{
+			Key:                       key,
+			TableName:                 aws.String(dao.tableName),
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+		},
+	}
+	if update {
+		userItem = types.TransactWriteItem{
+			Update: &types.Update{
+				Key:                       key,
+				TableName:                 aws.String(dao.tableName),
+				UpdateExpression:          expr.Update(),
+				ConditionExpression:       expr.Condition(),
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+			},
+		}
+	}
+
+	_, err = dao.DynamoDBClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{userItem, loginReservation, emailReservation},
+	})
+	if err != nil {
+		return transactionError(err, map[int]error{
+			0: ErrConcurrentModification,
+			1: ErrLoginTaken,
+			2: ErrEmailTaken,
+		})
+	}
+
+	return nil
+}
+
+// backfillReservation claims key for ownerID unless it already holds it, failing if
+// anyone else does.
+func (dao usersDAOImpl) backfillReservation(key, ownerID string) (types.TransactWriteItem, error) {
+	cond := expression.AttributeNotExists(expression.Name("userID")).
+		Or(expression.Name("ownerID").Equal(expression.Value(ownerID)))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+
+	return types.TransactWriteItem{
+		Put: &types.Put{
+			TableName: aws.String(dao.tableName),
+			Item: map[string]types.AttributeValue{
+				"userID":  &types.AttributeValueMemberS{Value: key},
+				"ownerID": &types.AttributeValueMemberS{Value: ownerID},
+			},
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+		},
+	}, nil
+}
@@ -0,0 +1,154 @@
+package daos
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"sync"
+	"time"
+)
+
+const (
+	// batchGetItemMaxKeys is the most keys DynamoDB accepts in a single BatchGetItem.
+	batchGetItemMaxKeys = 100
+	// Keys left unprocessed because of throttling are retried with exponential backoff.
+	batchGetMaxAttempts   = 5
+	batchGetRetryBaseWait = 50 * time.Millisecond
+	// loginQueryConcurrency bounds the LoginIndex queries run at once.
+	loginQueryConcurrency = 10
+)
+
+// ErrUnprocessedKeys is returned when DynamoDB kept throttling some keys of a batch.
+var ErrUnprocessedKeys = &Error{Kind: ErrThrottled, Err: errors.New("keys were left unprocessed after retrying")}
+
+func (dao usersDAOImpl) BatchGetUsersByID(ctx context.Context, ids []string) (map[string]User, error) {
+	users := map[string]User{}
+	for start := 0; start < len(ids); start += batchGetItemMaxKeys {
+		end := start + batchGetItemMaxKeys
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		keys := make([]map[string]types.AttributeValue, 0, end-start)
+		seen := map[string]bool{}
+		for _, id := range ids[start:end] {
+			// BatchGetItem refuses duplicate keys
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			keys = append(keys, map[string]types.AttributeValue{
+				"userID": &types.AttributeValueMemberS{Value: id},
+			})
+		}
+
+		items, err := dao.batchGetItems(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			var user User
+			err = attributevalue.UnmarshalMap(item, &user)
+			if err != nil {
+				return nil, corruptRecordError(err)
+			}
+
+			// Items stored next to the users have no login
+			if user.Login == "" || user.DeletedAt != nil {
+				continue
+			}
+			users[user.UserID] = user
+		}
+	}
+
+	return users, nil
+}
+
+// batchGetItems gets the items of up to batchGetItemMaxKeys keys, retrying unprocessed keys.
+func (dao usersDAOImpl) batchGetItems(ctx context.Context, keys []map[string]types.AttributeValue) ([]map[string]types.AttributeValue, error) {
+	var items []map[string]types.AttributeValue
+	requestItems := map[string]types.KeysAndAttributes{
+		dao.tableName: {Keys: keys},
+	}
+
+	for attempt := 0; len(requestItems) > 0; attempt++ {
+		if attempt == batchGetMaxAttempts {
+			return nil, ErrUnprocessedKeys
+		}
+
+		if attempt > 0 {
+			select {
+			case <-time.After(batchGetRetryBaseWait << (attempt - 1)):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		batchGetItemOutput, err := dao.DynamoDBClient.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+			RequestItems: requestItems,
+		})
+		if err != nil {
+			return nil, dynamoDBError(err)
+		}
+
+		items = append(items, batchGetItemOutput.Responses[dao.tableName]...)
+		requestItems = batchGetItemOutput.UnprocessedKeys
+	}
+
+	return items, nil
+}
+
+func (dao usersDAOImpl) BatchGetUsersByLogin(ctx context.Context, logins []string) (map[string]User, error) {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		users    = map[string]User{}
+		slots    = make(chan struct{}, loginQueryConcurrency)
+	)
+
+	// The LoginIndex can only be queried one login at a time
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	seen := map[string]bool{}
+	for _, login := range logins {
+		if seen[login] {
+			continue
+		}
+		seen[login] = true
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(login string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			user, err := dao.GetUserByLogin(ctx, login)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if errors.Is(err, ErrNotFound) {
+				return
+			}
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			users[login] = *user
+		}(login)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return users, nil
+}
@@ -0,0 +1,92 @@
+package daos
+
+import (
+	"golang.org/x/net/idna"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+// Logins and emails are unique and looked up by their canonical form, stored next to
+// the form the user chose. Two logins share a canonical form when they only differ by
+// case, compatibility characters such as fullwidth letters, or look-alike letters of
+// another script, so "Raider", "ｒａｉｄｅｒ" and "rаider" with a Cyrillic "а" are the
+// same login.
+
+var ErrConfusableLogin = invalidArgumentError("login must not mix letters of scripts that look alike")
+
+// confusables maps letters that are easily mistaken for latin letters once case folded.
+// It covers the common greek and cyrillic look-alikes of UTS #39 rather than the whole
+// confusables table.
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'с': 'c', 'ԁ': 'd', 'е': 'e', 'һ': 'h', 'і': 'i', 'ј': 'j', 'ӏ': 'l',
+	'о': 'o', 'р': 'p', 'ԛ': 'q', 'ѕ': 's', 'ѵ': 'v', 'ԝ': 'w', 'х': 'x', 'у': 'y',
+	// Greek
+	'α': 'a', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p',
+	'υ': 'u', 'χ': 'x', 'γ': 'y',
+	// Latin
+	'ı': 'i', 'ȷ': 'j', 'ɑ': 'a', 'ɡ': 'g',
+}
+
+// confusableScripts are the scripts whose letters can't be mixed in a login.
+var confusableScripts = []*unicode.RangeTable{unicode.Latin, unicode.Greek, unicode.Cyrillic}
+
+// foldCase case folds s. Casers are stateful, so a new one is used each time.
+func foldCase(s string) string {
+	return cases.Fold().String(s)
+}
+
+// CanonicalLogin returns the form of login compared for uniqueness and lookups: login
+// NFKC normalized, case folded and with look-alike letters replaced by latin ones.
+func CanonicalLogin(login string) string {
+	folded := norm.NFKC.String(foldCase(norm.NFKC.String(login)))
+	return strings.Map(func(r rune) rune {
+		if latin, ok := confusables[r]; ok {
+			return latin
+		}
+		return r
+	}, folded)
+}
+
+// checkLogin returns ErrConfusableLogin if login mixes latin, greek or cyrillic letters,
+// which is mostly done to impersonate other users.
+func checkLogin(login string) error {
+	var script *unicode.RangeTable
+	for _, r := range norm.NFKC.String(login) {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		for _, s := range confusableScripts {
+			if !unicode.Is(s, r) {
+				continue
+			}
+			if script != nil && script != s {
+				return ErrConfusableLogin
+			}
+			script = s
+		}
+	}
+	return nil
+}
+
+// CanonicalEmail returns the form of email compared for uniqueness and lookups. Both
+// parts are NFKC normalized and case folded, and internationalized domains are converted
+// to their ASCII form. Provider specific rules, such as dots and "+" tags being ignored
+// by some providers, are left alone.
+func CanonicalEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return foldCase(norm.NFKC.String(email))
+	}
+
+	local := foldCase(norm.NFKC.String(email[:at]))
+	domain, err := idna.Lookup.ToASCII(email[at+1:])
+	if err != nil {
+		// Invalid domains can't receive emails anyway, but are still kept unique
+		domain = foldCase(norm.NFKC.String(email[at+1:]))
+	}
+
+	return local + "@" + strings.ToLower(domain)
+}
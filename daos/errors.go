@@ -0,0 +1,105 @@
+package daos
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+)
+
+// Errors of the DAOs are one of these kinds, checked with errors.Is. The underlying
+// DynamoDB exception, when any, is still available through errors.As.
+var (
+	// ErrNotFound is returned when the requested item doesn't exist or was deleted.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when a write failed because the item changed meanwhile or
+	// holds a value already used by another item.
+	ErrConflict = errors.New("conflicting write")
+	// ErrThrottled is returned when DynamoDB throttled the request, which may be retried
+	// later.
+	ErrThrottled = errors.New("request throttled")
+	// ErrCorruptRecord is returned when a stored item can't be decoded.
+	ErrCorruptRecord = errors.New("corrupt record")
+	// ErrInvalidArgument is returned when an argument can't be used, e.g. a malformed
+	// page token.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrFailedPrecondition is returned when the item isn't in a state allowing the
+	// write, e.g. a user already has as many credentials as allowed.
+	ErrFailedPrecondition = errors.New("failed precondition")
+)
+
+// Error is an error of Kind caused by Err.
+type Error struct {
+	Kind error
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// conflictError returns an ErrConflict error with message, for errors callers tell apart.
+func conflictError(message string) error {
+	return &Error{Kind: ErrConflict, Err: errors.New(message)}
+}
+
+func notFoundError(message string) error {
+	return &Error{Kind: ErrNotFound, Err: errors.New(message)}
+}
+
+func invalidArgumentError(message string) error {
+	return &Error{Kind: ErrInvalidArgument, Err: errors.New(message)}
+}
+
+func failedPreconditionError(message string) error {
+	return &Error{Kind: ErrFailedPrecondition, Err: errors.New(message)}
+}
+
+func corruptRecordError(err error) error {
+	return &Error{Kind: ErrCorruptRecord, Err: err}
+}
+
+// dynamoDBError wraps the DynamoDB exceptions in err in the error of their kind.
+func dynamoDBError(err error) error {
+	var (
+		conditionFailed       *types.ConditionalCheckFailedException
+		transactionConflict   *types.TransactionConflictException
+		transactionInProgress *types.TransactionInProgressException
+		throughputExceeded    *types.ProvisionedThroughputExceededException
+		requestLimitExceeded  *types.RequestLimitExceeded
+		apiErr                smithy.APIError
+	)
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &conditionFailed), errors.As(err, &transactionConflict), errors.As(err, &transactionInProgress):
+		return &Error{Kind: ErrConflict, Err: err}
+	case errors.As(err, &throughputExceeded), errors.As(err, &requestLimitExceeded):
+		return &Error{Kind: ErrThrottled, Err: err}
+	case errors.As(err, &apiErr) && apiErr.ErrorCode() == "ThrottlingException":
+		return &Error{Kind: ErrThrottled, Err: err}
+	}
+
+	// Transactions are cancelled for conflicts with other transactions and throttling too
+	var cancelled *types.TransactionCanceledException
+	if errors.As(err, &cancelled) {
+		for _, reason := range cancelled.CancellationReasons {
+			switch aws.ToString(reason.Code) {
+			case "ConditionalCheckFailed", "TransactionConflict":
+				return &Error{Kind: ErrConflict, Err: err}
+			case "ThrottlingError", "ProvisionedThroughputExceeded":
+				return &Error{Kind: ErrThrottled, Err: err}
+			}
+		}
+	}
+
+	return err
+}
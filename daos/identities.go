@@ -0,0 +1,182 @@
+package daos
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"time"
+)
+
+// External identities are kept unique like logins and emails, with reservation items
+// keyed by IDENTITY#<provider>#<subject> pointing back to their user through ownerID.
+// The user lists its identities, at most one per provider.
+const identityReservationPrefix = "IDENTITY#"
+
+// ExternalIdentityProvider is an identity provider users can sign in with.
+type ExternalIdentityProvider string
+
+const (
+	ExternalIdentityProviderDiscord   ExternalIdentityProvider = "discord"
+	ExternalIdentityProviderBattleNet ExternalIdentityProvider = "battlenet"
+	ExternalIdentityProviderGoogle    ExternalIdentityProvider = "google"
+)
+
+var (
+	ErrExternalIdentityTaken     = conflictError("external identity is already linked to a user")
+	ErrExternalIdentityNotLinked = notFoundError("no external identity of the provider is linked")
+	// ErrProviderAlreadyLinked means the user already has an identity of the provider.
+	ErrProviderAlreadyLinked = failedPreconditionError("an external identity of the provider is already linked")
+)
+
+// ExternalIdentity is the account of a user at an identity provider. Subject is the
+// provider's stable ID of the account.
+type ExternalIdentity struct {
+	Provider ExternalIdentityProvider `dynamodbav:"provider"`
+	Subject  string                   `dynamodbav:"subject"`
+	LinkedAt time.Time                `dynamodbav:"linkedAt"`
+}
+
+func identityReservationKey(provider ExternalIdentityProvider, subject string) string {
+	return identityReservationPrefix + string(provider) + "#" + subject
+}
+
+// externalIdentity returns the user's identity of provider, if any.
+func (user User) externalIdentity(provider ExternalIdentityProvider) (ExternalIdentity, bool) {
+	for _, identity := range user.ExternalIdentities {
+		if identity.Provider == provider {
+			return identity, true
+		}
+	}
+	return ExternalIdentity{}, false
+}
+
+func (dao usersDAOImpl) LinkExternalIdentity(ctx context.Context, id string, identity ExternalIdentity) (*User, error) {
+	user, err := dao.getUserItem(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	if linked, ok := user.externalIdentity(identity.Provider); ok {
+		if linked.Subject == identity.Subject {
+			return user, nil
+		}
+		return nil, ErrProviderAlreadyLinked
+	}
+
+	putIdentity, err := dao.putReservation(identityReservationKey(identity.Provider, identity.Subject), id)
+	if err != nil {
+		return nil, err
+	}
+
+	identities := append(append([]ExternalIdentity{}, user.ExternalIdentities...), identity)
+	updateUser, updatedUser, err := dao.updateExternalIdentities(*user, identities)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = dao.DynamoDBClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{updateUser, putIdentity},
+	})
+	if err != nil {
+		return nil, transactionError(err, map[int]error{
+			0: ErrConcurrentModification,
+			1: ErrExternalIdentityTaken,
+		})
+	}
+
+	return &updatedUser, nil
+}
+
+func (dao usersDAOImpl) UnlinkExternalIdentity(ctx context.Context, id string, provider ExternalIdentityProvider) (*User, error) {
+	user, err := dao.getUserItem(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	linked, ok := user.externalIdentity(provider)
+	if !ok {
+		return nil, ErrExternalIdentityNotLinked
+	}
+
+	deleteIdentity, err := dao.deleteReservation(identityReservationKey(linked.Provider, linked.Subject), id)
+	if err != nil {
+		return nil, err
+	}
+
+	var identities []ExternalIdentity
+	for _, identity := range user.ExternalIdentities {
+		if identity.Provider != provider {
+			identities = append(identities, identity)
+		}
+	}
+	updateUser, updatedUser, err := dao.updateExternalIdentities(*user, identities)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = dao.DynamoDBClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{updateUser, deleteIdentity},
+	})
+	if err != nil {
+		return nil, transactionError(err, map[int]error{
+			0: ErrConcurrentModification,
+			1: ErrConcurrentModification,
+		})
+	}
+
+	return &updatedUser, nil
+}
+
+// updateExternalIdentities replaces the identities of user, failing if the user changed
+// since it was read. It returns the transaction item along with the updated user.
+func (dao usersDAOImpl) updateExternalIdentities(user User, identities []ExternalIdentity) (types.TransactWriteItem, User, error) {
+	now := time.Now()
+	updateExpr := expression.Set(expression.Name("updatedAt"), expression.Value(now))
+	if len(identities) > 0 {
+		updateExpr = updateExpr.Set(expression.Name("externalIdentities"), expression.Value(identities))
+	} else {
+		updateExpr = updateExpr.Remove(expression.Name("externalIdentities"))
+	}
+	cond := expression.AttributeNotExists(expression.Name("deletedAt")).
+		And(expression.Name("updatedAt").Equal(expression.Value(user.UpdatedAt)))
+	expr, err := expression.NewBuilder().WithUpdate(updateExpr).WithCondition(cond).Build()
+	if err != nil {
+		return types.TransactWriteItem{}, User{}, err
+	}
+
+	user.ExternalIdentities = identities
+	user.UpdatedAt = now
+
+	return types.TransactWriteItem{
+		Update: &types.Update{
+			Key: map[string]types.AttributeValue{
+				"userID": &types.AttributeValueMemberS{Value: user.UserID},
+			},
+			TableName:                 aws.String(dao.tableName),
+			UpdateExpression:          expr.Update(),
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+		},
+	}, user, nil
+}
+
+func (dao usersDAOImpl) GetUserByExternalIdentity(ctx context.Context, provider ExternalIdentityProvider, subject string) (*User, error) {
+	ownerID, err := dao.getReservationOwner(ctx, identityReservationKey(provider, subject))
+	if err != nil {
+		return nil, err
+	}
+	if ownerID == "" {
+		return nil, errUserNotFound
+	}
+
+	return dao.GetUserByID(ctx, ownerID)
+}
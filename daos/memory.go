@@ -0,0 +1,771 @@
+package daos
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// inMemoryUsersDAO is a UsersDAO kept in process memory for tests and local development.
+// It mirrors the DynamoDB implementation: logins and emails are reserved with the same
+// canonical keys, and returns the same errors.
+type inMemoryUsersDAO struct {
+	mu sync.RWMutex
+
+	users               map[string]User
+	reservations        map[string]string
+	webAuthnCredentials map[string]WebAuthnCredential
+}
+
+func NewInMemoryUsersDAO() UsersDAO {
+	return &inMemoryUsersDAO{
+		users:               map[string]User{},
+		reservations:        map[string]string{},
+		webAuthnCredentials: map[string]WebAuthnCredential{},
+	}
+}
+
+func (dao *inMemoryUsersDAO) CreateUser(ctx context.Context, login, email, hashedPassword string, identities ...ExternalIdentity) (User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	err := checkLogin(login)
+	if err != nil {
+		return User{}, err
+	}
+
+	canonicalLogin, canonicalEmail := CanonicalLogin(login), CanonicalEmail(email)
+	if _, ok := dao.reservations[loginReservationKey(canonicalLogin)]; ok {
+		return User{}, ErrLoginTaken
+	}
+	if _, ok := dao.reservations[emailReservationKey(canonicalEmail)]; ok {
+		return User{}, ErrEmailTaken
+	}
+	for _, identity := range identities {
+		if _, ok := dao.reservations[identityReservationKey(identity.Provider, identity.Subject)]; ok {
+			return User{}, ErrExternalIdentityTaken
+		}
+	}
+
+	now := time.Now()
+	newUser := User{
+		UserID:             uuid.NewString(),
+		Login:              login,
+		Email:              email,
+		CanonicalLogin:     canonicalLogin,
+		CanonicalEmail:     canonicalEmail,
+		LoginSearchShard:   loginSearchShard(canonicalLogin),
+		HashedPassword:     hashedPassword,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		ExternalIdentities: identities,
+	}
+
+	dao.users[newUser.UserID] = newUser
+	dao.reservations[loginReservationKey(canonicalLogin)] = newUser.UserID
+	dao.reservations[emailReservationKey(canonicalEmail)] = newUser.UserID
+	for _, identity := range identities {
+		dao.reservations[identityReservationKey(identity.Provider, identity.Subject)] = newUser.UserID
+	}
+
+	return newUser, nil
+}
+
+func (dao *inMemoryUsersDAO) GetUserByID(ctx context.Context, id string) (*User, error) {
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) GetUserByLogin(ctx context.Context, login string) (*User, error) {
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+
+	canonicalLogin := CanonicalLogin(login)
+	for _, user := range dao.users {
+		if user.CanonicalLogin == canonicalLogin && user.DeletedAt == nil {
+			return &user, nil
+		}
+	}
+
+	return nil, errUserNotFound
+}
+
+func (dao *inMemoryUsersDAO) GetUsersByEmail(ctx context.Context, email string) ([]User, error) {
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+
+	var users []User
+	canonicalEmail := CanonicalEmail(email)
+	for _, user := range dao.users {
+		if user.CanonicalEmail == canonicalEmail && user.DeletedAt == nil {
+			users = append(users, user)
+		}
+	}
+
+	return users, nil
+}
+
+func (dao *inMemoryUsersDAO) UpdateUser(ctx context.Context, id string, update UserUpdate) (*User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	if update.Login != nil && *update.Login != user.Login {
+		err := checkLogin(*update.Login)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	loginChanged := update.Login != nil && CanonicalLogin(*update.Login) != user.CanonicalLogin
+	emailChanged := update.Email != nil && CanonicalEmail(*update.Email) != user.CanonicalEmail
+	if loginChanged {
+		if _, ok := dao.reservations[loginReservationKey(CanonicalLogin(*update.Login))]; ok {
+			return nil, ErrLoginTaken
+		}
+	}
+	if emailChanged {
+		if _, ok := dao.reservations[emailReservationKey(CanonicalEmail(*update.Email))]; ok {
+			return nil, ErrEmailTaken
+		}
+	}
+
+	if loginChanged {
+		delete(dao.reservations, loginReservationKey(user.CanonicalLogin))
+		user.CanonicalLogin = CanonicalLogin(*update.Login)
+		user.LoginSearchShard = loginSearchShard(user.CanonicalLogin)
+		dao.reservations[loginReservationKey(user.CanonicalLogin)] = id
+	}
+	if update.Login != nil {
+		user.Login = *update.Login
+	}
+	if emailChanged {
+		delete(dao.reservations, emailReservationKey(user.CanonicalEmail))
+		user.CanonicalEmail = CanonicalEmail(*update.Email)
+		user.EmailVerified = false
+		dao.reservations[emailReservationKey(user.CanonicalEmail)] = id
+	}
+	if update.Email != nil {
+		user.Email = *update.Email
+	}
+	now := time.Now()
+	if update.HashedPassword != nil {
+		user.HashedPassword = *update.HashedPassword
+	}
+	if update.RevokeSessions {
+		user.SessionsRevokedAt = &now
+	}
+	user.UpdatedAt = now
+
+	dao.users[id] = user
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) DeleteUser(ctx context.Context, id string, purge bool) (*User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok {
+		return nil, errUserNotFound
+	}
+
+	if purge {
+		delete(dao.users, id)
+		delete(dao.reservations, loginReservationKey(user.CanonicalLogin))
+		delete(dao.reservations, emailReservationKey(user.CanonicalEmail))
+		for _, credentialID := range user.WebAuthnCredentialIDs {
+			delete(dao.webAuthnCredentials, credentialID)
+		}
+		for _, identity := range user.ExternalIdentities {
+			delete(dao.reservations, identityReservationKey(identity.Provider, identity.Subject))
+		}
+		return &user, nil
+	}
+
+	if user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	now := time.Now()
+	user.DeletedAt = &now
+	user.UpdatedAt = now
+	dao.users[id] = user
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) RecordFailedLogin(ctx context.Context, id string) (*User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	now := time.Now()
+	user.FailedLoginAttempts++
+	user.LastFailedLoginAt = &now
+	dao.users[id] = user
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) LockUser(ctx context.Context, id string, until time.Time) error {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil {
+		return errUserNotFound
+	}
+
+	user.LockedUntil = &until
+	dao.users[id] = user
+
+	return nil
+}
+
+func (dao *inMemoryUsersDAO) ResetFailedLogins(ctx context.Context, id string) error {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil {
+		return errUserNotFound
+	}
+
+	user.FailedLoginAttempts = 0
+	user.LastFailedLoginAt = nil
+	user.LockedUntil = nil
+	dao.users[id] = user
+
+	return nil
+}
+
+func (dao *inMemoryUsersDAO) MarkEmailVerified(ctx context.Context, id, email string) (*User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+	if user.CanonicalEmail != CanonicalEmail(email) {
+		return nil, ErrEmailChanged
+	}
+
+	user.EmailVerified = true
+	user.UpdatedAt = time.Now()
+	dao.users[id] = user
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) SetPendingTOTPSecret(ctx context.Context, id, encryptedSecret string) (*User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTOTPEnabled
+	}
+
+	user.TOTPSecret = encryptedSecret
+	user.UpdatedAt = time.Now()
+	dao.users[id] = user
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) EnableTOTP(ctx context.Context, id, encryptedSecret string, step int64) (*User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+	if user.TOTPEnabled || user.TOTPSecret != encryptedSecret {
+		return nil, ErrConcurrentModification
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+	user.UpdatedAt = time.Now()
+	dao.users[id] = user
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) DisableTOTP(ctx context.Context, id string) (*User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	user.TOTPSecret = ""
+	user.TOTPEnabled = false
+	user.TOTPLastStep = 0
+	user.RecoveryCodeHashes = nil
+	user.UpdatedAt = time.Now()
+	dao.users[id] = user
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) RecordTOTPStep(ctx context.Context, id string, step int64) (bool, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil || !user.TOTPEnabled || user.TOTPLastStep >= step {
+		return false, nil
+	}
+
+	user.TOTPLastStep = step
+	dao.users[id] = user
+
+	return true, nil
+}
+
+func (dao *inMemoryUsersDAO) SetRecoveryCodes(ctx context.Context, id string, codeHashes []string) (*User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+	if !user.TOTPEnabled {
+		return nil, ErrTOTPNotEnabled
+	}
+
+	user.RecoveryCodeHashes = map[string]bool{}
+	for _, codeHash := range codeHashes {
+		user.RecoveryCodeHashes[codeHash] = true
+	}
+	user.UpdatedAt = time.Now()
+	dao.users[id] = user
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) ConsumeRecoveryCode(ctx context.Context, id, codeHash string) (*User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil || !user.RecoveryCodeHashes[codeHash] {
+		return nil, ErrRecoveryCodeNotFound
+	}
+
+	// Copy the map so users returned earlier keep their codes
+	hashes := map[string]bool{}
+	for hash := range user.RecoveryCodeHashes {
+		if hash != codeHash {
+			hashes[hash] = true
+		}
+	}
+	user.RecoveryCodeHashes = hashes
+	user.UpdatedAt = time.Now()
+	dao.users[id] = user
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) SetUserRoles(ctx context.Context, id string, roles []string) (*User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	user.Roles = nil
+	if len(roles) > 0 {
+		user.Roles = append([]string{}, roles...)
+	}
+	user.UpdatedAt = time.Now()
+	dao.users[id] = user
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) AddWebAuthnCredential(ctx context.Context, credential WebAuthnCredential) (*User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[credential.UserID]
+	if !ok || user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	if len(user.WebAuthnCredentialIDs) >= MaxWebAuthnCredentials {
+		return nil, ErrTooManyWebAuthnCredentials
+	}
+
+	if _, ok := dao.webAuthnCredentials[credential.CredentialID]; ok {
+		return nil, ErrWebAuthnCredentialTaken
+	}
+
+	dao.webAuthnCredentials[credential.CredentialID] = credential
+	// Copy the slice so users returned earlier keep their credentials
+	user.WebAuthnCredentialIDs = append(append([]string{}, user.WebAuthnCredentialIDs...), credential.CredentialID)
+	user.UpdatedAt = time.Now()
+	dao.users[user.UserID] = user
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) GetWebAuthnCredential(ctx context.Context, credentialID string) (*WebAuthnCredential, error) {
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+
+	credential, ok := dao.webAuthnCredentials[credentialID]
+	if !ok {
+		return nil, errWebAuthnCredentialNotFound
+	}
+
+	return &credential, nil
+}
+
+func (dao *inMemoryUsersDAO) UpdateWebAuthnSignCount(ctx context.Context, credentialID string, oldSignCount, newSignCount uint32) (bool, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	credential, ok := dao.webAuthnCredentials[credentialID]
+	if !ok || credential.SignCount != oldSignCount {
+		return false, nil
+	}
+
+	now := time.Now()
+	credential.SignCount = newSignCount
+	credential.LastUsedAt = &now
+	dao.webAuthnCredentials[credentialID] = credential
+
+	return true, nil
+}
+
+func (dao *inMemoryUsersDAO) LinkExternalIdentity(ctx context.Context, id string, identity ExternalIdentity) (*User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	if linked, ok := user.externalIdentity(identity.Provider); ok {
+		if linked.Subject == identity.Subject {
+			return &user, nil
+		}
+		return nil, ErrProviderAlreadyLinked
+	}
+
+	key := identityReservationKey(identity.Provider, identity.Subject)
+	if _, ok := dao.reservations[key]; ok {
+		return nil, ErrExternalIdentityTaken
+	}
+
+	dao.reservations[key] = id
+	user.ExternalIdentities = append(append([]ExternalIdentity{}, user.ExternalIdentities...), identity)
+	user.UpdatedAt = time.Now()
+	dao.users[id] = user
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) UnlinkExternalIdentity(ctx context.Context, id string, provider ExternalIdentityProvider) (*User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	user, ok := dao.users[id]
+	if !ok || user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	linked, ok := user.externalIdentity(provider)
+	if !ok {
+		return nil, ErrExternalIdentityNotLinked
+	}
+
+	delete(dao.reservations, identityReservationKey(linked.Provider, linked.Subject))
+	var identities []ExternalIdentity
+	for _, identity := range user.ExternalIdentities {
+		if identity.Provider != provider {
+			identities = append(identities, identity)
+		}
+	}
+	user.ExternalIdentities = identities
+	user.UpdatedAt = time.Now()
+	dao.users[id] = user
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) GetUserByExternalIdentity(ctx context.Context, provider ExternalIdentityProvider, subject string) (*User, error) {
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+
+	user, ok := dao.users[dao.reservations[identityReservationKey(provider, subject)]]
+	if !ok || user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	return &user, nil
+}
+
+func (dao *inMemoryUsersDAO) ListUsers(ctx context.Context, filter ListUsersFilter, pageSize int, pageToken string) ([]User, string, error) {
+	startKey, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var lastUserID string
+	if startKey != nil {
+		err = attributevalue.Unmarshal(startKey["userID"], &lastUserID)
+		if err != nil {
+			return nil, "", ErrInvalidPageToken
+		}
+	}
+
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+
+	// Users are listed by ID so pages can continue after the last user of the previous one
+	var matches []User
+	for _, user := range dao.users {
+		if user.UserID <= lastUserID || user.DeletedAt != nil ||
+			(filter.CreatedAfter != nil && user.CreatedAt.Before(*filter.CreatedAfter)) ||
+			(filter.CreatedBefore != nil && !user.CreatedAt.Before(*filter.CreatedBefore)) ||
+			!strings.HasPrefix(user.CanonicalLogin, CanonicalLogin(filter.LoginPrefix)) {
+			continue
+		}
+		matches = append(matches, user)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].UserID < matches[j].UserID
+	})
+
+	if len(matches) <= pageSize {
+		return matches, "", nil
+	}
+
+	users := matches[:pageSize]
+	nextPageToken, err := encodePageToken(map[string]types.AttributeValue{
+		"userID": &types.AttributeValueMemberS{Value: users[len(users)-1].UserID},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return users, nextPageToken, nil
+}
+
+func (dao *inMemoryUsersDAO) BatchGetUsersByID(ctx context.Context, ids []string) (map[string]User, error) {
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+
+	users := map[string]User{}
+	for _, id := range ids {
+		if user, ok := dao.users[id]; ok && user.DeletedAt == nil {
+			users[id] = user
+		}
+	}
+
+	return users, nil
+}
+
+func (dao *inMemoryUsersDAO) BatchGetUsersByLogin(ctx context.Context, logins []string) (map[string]User, error) {
+	users := map[string]User{}
+	for _, login := range logins {
+		user, err := dao.GetUserByLogin(ctx, login)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		users[login] = *user
+	}
+
+	return users, nil
+}
+
+func (dao *inMemoryUsersDAO) SearchByLoginPrefix(ctx context.Context, prefix string, limit int, pageToken string) ([]User, string, error) {
+	canonicalPrefix := CanonicalLogin(prefix)
+	if len([]rune(canonicalPrefix)) < MinLoginSearchPrefixLength {
+		return nil, "", ErrSearchPrefixTooShort
+	}
+
+	startKey, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var lastCanonicalLogin string
+	if startKey != nil {
+		err = attributevalue.Unmarshal(startKey["canonicalLogin"], &lastCanonicalLogin)
+		if err != nil {
+			return nil, "", ErrInvalidPageToken
+		}
+	}
+
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+
+	var matches []User
+	for _, user := range dao.users {
+		if user.DeletedAt == nil && user.CanonicalLogin > lastCanonicalLogin && strings.HasPrefix(user.CanonicalLogin, canonicalPrefix) {
+			matches = append(matches, user)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CanonicalLogin < matches[j].CanonicalLogin
+	})
+
+	if len(matches) <= limit {
+		return matches, "", nil
+	}
+
+	users := matches[:limit]
+	nextPageToken, err := encodePageToken(map[string]types.AttributeValue{
+		"canonicalLogin": &types.AttributeValueMemberS{Value: users[len(users)-1].CanonicalLogin},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return users, nextPageToken, nil
+}
+
+// inMemorySessionsDAO is a SessionsDAO kept in process memory for tests and local development.
+type inMemorySessionsDAO struct {
+	mu sync.Mutex
+
+	sessions map[string]Session
+}
+
+func NewInMemorySessionsDAO() SessionsDAO {
+	return &inMemorySessionsDAO{
+		sessions: map[string]Session{},
+	}
+}
+
+func (dao *inMemorySessionsDAO) CreateSession(ctx context.Context, session Session) error {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	if _, ok := dao.sessions[session.TokenHash]; ok {
+		return conflictError("session already exists")
+	}
+
+	dao.sessions[session.TokenHash] = session
+	return nil
+}
+
+func (dao *inMemorySessionsDAO) GetSession(ctx context.Context, tokenHash string) (*Session, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	session, ok := dao.sessions[tokenHash]
+	if !ok {
+		return nil, errSessionNotFound
+	}
+
+	return &session, nil
+}
+
+func (dao *inMemorySessionsDAO) RotateSession(ctx context.Context, oldTokenHash string, newSession Session) (bool, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	if _, ok := dao.sessions[oldTokenHash]; !ok {
+		return false, nil
+	}
+	if _, ok := dao.sessions[newSession.TokenHash]; ok {
+		return false, conflictError("session already exists")
+	}
+
+	delete(dao.sessions, oldTokenHash)
+	dao.sessions[newSession.TokenHash] = newSession
+	return true, nil
+}
+
+func (dao *inMemorySessionsDAO) DeleteSession(ctx context.Context, tokenHash string) (*Session, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	session, ok := dao.sessions[tokenHash]
+	if !ok {
+		return nil, errSessionNotFound
+	}
+
+	delete(dao.sessions, tokenHash)
+	return &session, nil
+}
+
+// inMemoryTokensDAO is a TokensDAO kept in process memory for tests and local development.
+type inMemoryTokensDAO struct {
+	mu sync.Mutex
+
+	tokens map[string]Token
+}
+
+func NewInMemoryTokensDAO() TokensDAO {
+	return &inMemoryTokensDAO{
+		tokens: map[string]Token{},
+	}
+}
+
+func (dao *inMemoryTokensDAO) CreateToken(ctx context.Context, token Token) error {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	if _, ok := dao.tokens[token.TokenHash]; ok {
+		return conflictError("token already exists")
+	}
+
+	dao.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (dao *inMemoryTokensDAO) ConsumeToken(ctx context.Context, purpose TokenPurpose, tokenHash string) (*Token, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	token, ok := dao.tokens[tokenHash]
+	if !ok || token.Purpose != purpose {
+		return nil, errTokenNotFound
+	}
+
+	delete(dao.tokens, tokenHash)
+	return &token, nil
+}
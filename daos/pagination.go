@@ -0,0 +1,62 @@
+package daos
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Pages of results continue from a page token wrapping the LastEvaluatedKey of the
+// previous page, so callers don't depend on the table's keys.
+
+// maxScansPerPage bounds the reads of a single page when filters skip most items. The
+// page is then returned short, with a page token to continue from.
+const maxScansPerPage = 10
+
+var ErrInvalidPageToken = invalidArgumentError("page token is invalid")
+
+func encodePageToken(lastEvaluatedKey map[string]types.AttributeValue) (string, error) {
+	if len(lastEvaluatedKey) == 0 {
+		return "", nil
+	}
+
+	var key map[string]interface{}
+	err := attributevalue.UnmarshalMap(lastEvaluatedKey, &key)
+	if err != nil {
+		return "", err
+	}
+
+	encoded, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+// decodePageToken returns the key to start the next page from, or nil for the first page.
+func decodePageToken(pageToken string) (map[string]types.AttributeValue, error) {
+	if pageToken == "" {
+		return nil, nil
+	}
+
+	encoded, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	// Keys are made of strings only
+	var key map[string]string
+	err = json.Unmarshal(encoded, &key)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidPageToken
+	}
+
+	exclusiveStartKey, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	return exclusiveStartKey, nil
+}
@@ -0,0 +1,99 @@
+package daos
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Logins and emails are kept unique with reservation items stored in the users table
+// next to the users themselves. They are keyed by LOGIN#<login> or EMAIL#<email> and
+// point back to the user through ownerID. Reservations are written in the same
+// transaction as the user so concurrent writes can't claim the same login or email.
+const (
+	loginReservationPrefix = "LOGIN#"
+	emailReservationPrefix = "EMAIL#"
+)
+
+var (
+	ErrLoginTaken             = conflictError("login is already in use")
+	ErrEmailTaken             = conflictError("email is already in use")
+	ErrConcurrentModification = conflictError("user was modified concurrently")
+)
+
+func loginReservationKey(login string) string {
+	return loginReservationPrefix + login
+}
+
+func emailReservationKey(email string) string {
+	return emailReservationPrefix + email
+}
+
+func reservationKey(key string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"userID": &types.AttributeValueMemberS{Value: key},
+	}
+}
+
+// putReservation claims key for ownerID, failing if anyone already holds it.
+func (dao usersDAOImpl) putReservation(key, ownerID string) (types.TransactWriteItem, error) {
+	cond := expression.AttributeNotExists(expression.Name("userID"))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+
+	return types.TransactWriteItem{
+		Put: &types.Put{
+			TableName: aws.String(dao.tableName),
+			Item: map[string]types.AttributeValue{
+				"userID":  &types.AttributeValueMemberS{Value: key},
+				"ownerID": &types.AttributeValueMemberS{Value: ownerID},
+			},
+			ConditionExpression:      expr.Condition(),
+			ExpressionAttributeNames: expr.Names(),
+		},
+	}, nil
+}
+
+// deleteReservation releases key if it is held by ownerID. Missing reservations are
+// ignored so users created before reservations existed can still be changed.
+func (dao usersDAOImpl) deleteReservation(key, ownerID string) (types.TransactWriteItem, error) {
+	cond := expression.AttributeNotExists(expression.Name("userID")).
+		Or(expression.Name("ownerID").Equal(expression.Value(ownerID)))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+
+	return types.TransactWriteItem{
+		Delete: &types.Delete{
+			TableName:                 aws.String(dao.tableName),
+			Key:                       reservationKey(key),
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+		},
+	}, nil
+}
+
+// getReservationOwner returns the ID of the user holding key, or an empty string if the
+// key is free.
+func (dao usersDAOImpl) getReservationOwner(ctx context.Context, key string) (string, error) {
+	getItemOutput, err := dao.DynamoDBClient.GetItem(ctx, &dynamodb.GetItemInput{
+		Key:       reservationKey(key),
+		TableName: aws.String(dao.tableName),
+	})
+	if err != nil {
+		return "", dynamoDBError(err)
+	}
+
+	ownerID, ok := getItemOutput.Item["ownerID"].(*types.AttributeValueMemberS)
+	if !ok {
+		return "", nil
+	}
+
+	return ownerID.Value, nil
+}
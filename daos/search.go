@@ -0,0 +1,88 @@
+package daos
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// Logins are searched by prefix through the LoginPrefixIndex, keyed by the first
+// characters of the canonical login and sorted by the whole canonical login. The
+// leading characters spread users across partitions while every prefix at least as long
+// stays within a single one.
+const LOGIN_PREFIX_INDEX = "LoginPrefixIndex"
+
+// MinLoginSearchPrefixLength is the length of the shard key, which every searched
+// prefix must contain.
+const MinLoginSearchPrefixLength = 2
+
+var ErrSearchPrefixTooShort = invalidArgumentError(fmt.Sprintf("login prefix must be at least %d characters", MinLoginSearchPrefixLength))
+
+// loginSearchShard returns the LoginPrefixIndex hash key of canonicalLogin.
+func loginSearchShard(canonicalLogin string) string {
+	runes := []rune(canonicalLogin)
+	if len(runes) < MinLoginSearchPrefixLength {
+		return canonicalLogin
+	}
+	return string(runes[:MinLoginSearchPrefixLength])
+}
+
+func (dao usersDAOImpl) SearchByLoginPrefix(ctx context.Context, prefix string, limit int, pageToken string) ([]User, string, error) {
+	canonicalPrefix := CanonicalLogin(prefix)
+	if len([]rune(canonicalPrefix)) < MinLoginSearchPrefixLength {
+		return nil, "", ErrSearchPrefixTooShort
+	}
+
+	exclusiveStartKey, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	keyCond := expression.Key("loginSearchShard").Equal(expression.Value(loginSearchShard(canonicalPrefix))).
+		And(expression.Key("canonicalLogin").BeginsWith(canonicalPrefix))
+	filter := expression.AttributeNotExists(expression.Name("deletedAt"))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).WithFilter(filter).Build()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Read no more items than users missing, like ListUsers
+	var users []User
+	for i := 0; i < maxScansPerPage && len(users) < limit; i++ {
+		queryOutput, err := dao.DynamoDBClient.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(dao.tableName),
+			IndexName:                 aws.String(dao.loginPrefixIndex),
+			KeyConditionExpression:    expr.KeyCondition(),
+			FilterExpression:          expr.Filter(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			ExclusiveStartKey:         exclusiveStartKey,
+			Limit:                     aws.Int32(int32(limit - len(users))),
+		})
+		if err != nil {
+			return nil, "", dynamoDBError(err)
+		}
+
+		var pageUsers []User
+		err = attributevalue.UnmarshalListOfMaps(queryOutput.Items, &pageUsers)
+		if err != nil {
+			return nil, "", corruptRecordError(err)
+		}
+		users = append(users, pageUsers...)
+
+		exclusiveStartKey = queryOutput.LastEvaluatedKey
+		if exclusiveStartKey == nil {
+			break
+		}
+	}
+
+	nextPageToken, err := encodePageToken(exclusiveStartKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return users, nextPageToken, nil
+}
@@ -0,0 +1,178 @@
+package daos
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"time"
+)
+
+// Sessions are stored in the users table keyed by SESSION#<tokenHash>. Expired sessions
+// are removed by DynamoDB through the ttl attribute.
+const sessionPrefix = "SESSION#"
+
+var errSessionNotFound = notFoundError("session not found")
+
+// Session is a refresh token issued to a user. Only the hash of the token is stored.
+type Session struct {
+	TokenHash string    `dynamodbav:"tokenHash"`
+	UserID    string    `dynamodbav:"ownerID"`
+	CreatedAt time.Time `dynamodbav:"createdAt"`
+	ExpiresAt time.Time `dynamodbav:"expiresAt"`
+}
+
+type sessionItem struct {
+	Key string `dynamodbav:"userID"`
+	TTL int64  `dynamodbav:"ttl"`
+	Session
+}
+
+func sessionKey(tokenHash string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"userID": &types.AttributeValueMemberS{Value: sessionPrefix + tokenHash},
+	}
+}
+
+type SessionsDAO interface {
+	CreateSession(ctx context.Context, session Session) error
+	GetSession(ctx context.Context, tokenHash string) (*Session, error)
+	// RotateSession replaces the session for oldTokenHash with newSession. It returns
+	// false if the old session no longer exists, e.g. because it was already rotated.
+	RotateSession(ctx context.Context, oldTokenHash string, newSession Session) (bool, error)
+	DeleteSession(ctx context.Context, tokenHash string) (*Session, error)
+}
+
+type sessionsDAOImpl struct {
+	DynamoDBClient *dynamodb.Client
+
+	tableName string
+}
+
+func NewSessionsDAO(dynamoDBClient *dynamodb.Client, tables Tables) SessionsDAO {
+	return &sessionsDAOImpl{
+		DynamoDBClient: dynamoDBClient,
+		tableName:      tables.Table,
+	}
+}
+
+func (dao sessionsDAOImpl) putSession(session Session) (*types.Put, error) {
+	item, err := attributevalue.MarshalMap(sessionItem{
+		Key:     sessionPrefix + session.TokenHash,
+		TTL:     session.ExpiresAt.Unix(),
+		Session: session,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cond := expression.AttributeNotExists(expression.Name("userID"))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Put{
+		TableName:                aws.String(dao.tableName),
+		Item:                     item,
+		ConditionExpression:      expr.Condition(),
+		ExpressionAttributeNames: expr.Names(),
+	}, nil
+}
+
+func (dao sessionsDAOImpl) CreateSession(ctx context.Context, session Session) error {
+	put, err := dao.putSession(session)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.DynamoDBClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                put.TableName,
+		Item:                     put.Item,
+		ConditionExpression:      put.ConditionExpression,
+		ExpressionAttributeNames: put.ExpressionAttributeNames,
+	})
+	return dynamoDBError(err)
+}
+
+func (dao sessionsDAOImpl) GetSession(ctx context.Context, tokenHash string) (*Session, error) {
+	getItemOutput, err := dao.DynamoDBClient.GetItem(ctx, &dynamodb.GetItemInput{
+		Key:            sessionKey(tokenHash),
+		TableName:      aws.String(dao.tableName),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, dynamoDBError(err)
+	}
+
+	if getItemOutput.Item == nil {
+		return nil, errSessionNotFound
+	}
+
+	item := &sessionItem{}
+	err = attributevalue.UnmarshalMap(getItemOutput.Item, item)
+	if err != nil {
+		return nil, corruptRecordError(err)
+	}
+
+	return &item.Session, nil
+}
+
+func (dao sessionsDAOImpl) RotateSession(ctx context.Context, oldTokenHash string, newSession Session) (bool, error) {
+	put, err := dao.putSession(newSession)
+	if err != nil {
+		return false, err
+	}
+
+	cond := expression.AttributeExists(expression.Name("userID"))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = dao.DynamoDBClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Delete: &types.Delete{
+					TableName:                aws.String(dao.tableName),
+					Key:                      sessionKey(oldTokenHash),
+					ConditionExpression:      expr.Condition(),
+					ExpressionAttributeNames: expr.Names(),
+				},
+			},
+			{Put: put},
+		},
+	})
+	if i, ok := failedCondition(err); ok && i == 0 {
+		return false, nil
+	} else if err != nil {
+		return false, dynamoDBError(err)
+	}
+
+	return true, nil
+}
+
+func (dao sessionsDAOImpl) DeleteSession(ctx context.Context, tokenHash string) (*Session, error) {
+	deleteItemOutput, err := dao.DynamoDBClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		Key:          sessionKey(tokenHash),
+		TableName:    aws.String(dao.tableName),
+		ReturnValues: types.ReturnValueAllOld,
+	})
+	if err != nil {
+		return nil, dynamoDBError(err)
+	}
+
+	if deleteItemOutput.Attributes == nil {
+		return nil, errSessionNotFound
+	}
+
+	item := &sessionItem{}
+	err = attributevalue.UnmarshalMap(deleteItemOutput.Attributes, item)
+	if err != nil {
+		return nil, corruptRecordError(err)
+	}
+
+	return &item.Session, nil
+}
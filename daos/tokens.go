@@ -0,0 +1,142 @@
+package daos
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"time"
+)
+
+// Single-use tokens handed to users are stored in the users table keyed by
+// TOKEN#<tokenHash>. Expired tokens are removed by DynamoDB through the ttl attribute.
+const tokenPrefix = "TOKEN#"
+
+var errTokenNotFound = notFoundError("token not found")
+
+// TokenPurpose is what a token can be consumed for.
+type TokenPurpose string
+
+const (
+	TokenPurposeVerifyEmail   TokenPurpose = "verifyEmail"
+	TokenPurposeResetPassword TokenPurpose = "resetPassword"
+	// Second factor tokens are handed out instead of a result by password checks of users
+	// with a second factor, and are redeemed for the result along with the second factor.
+	TokenPurposeSecondFactor TokenPurpose = "secondFactor"
+	// WebAuthn challenges are tokens too. UserID is empty for assertions of discoverable
+	// credentials, where the user is only known once the authenticator responds.
+	TokenPurposeWebAuthnRegistration TokenPurpose = "webAuthnRegistration"
+	TokenPurposeWebAuthnAssertion    TokenPurpose = "webAuthnAssertion"
+)
+
+// Token is a single-use token handed to a user. Only the hash of the token is stored.
+type Token struct {
+	TokenHash string       `dynamodbav:"tokenHash"`
+	Purpose   TokenPurpose `dynamodbav:"purpose"`
+	UserID    string       `dynamodbav:"ownerID"`
+	// SentTo is the email address the token was sent to. It is not stored as email
+	// so tokens stay out of the EmailIndex.
+	SentTo string `dynamodbav:"sentTo,omitempty"`
+	// IssueSession is set on second factor tokens handed out by Login, so a session is
+	// issued once the second factor is verified.
+	IssueSession bool      `dynamodbav:"issueSession,omitempty"`
+	CreatedAt    time.Time `dynamodbav:"createdAt"`
+	ExpiresAt    time.Time `dynamodbav:"expiresAt"`
+}
+
+type tokenItem struct {
+	Key string `dynamodbav:"userID"`
+	TTL int64  `dynamodbav:"ttl"`
+	Token
+}
+
+func tokenKey(tokenHash string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"userID": &types.AttributeValueMemberS{Value: tokenPrefix + tokenHash},
+	}
+}
+
+type TokensDAO interface {
+	CreateToken(ctx context.Context, token Token) error
+	// ConsumeToken deletes and returns the token if it exists for purpose, or returns an
+	// ErrNotFound error.
+	// Expired tokens may still be returned until DynamoDB removes them.
+	ConsumeToken(ctx context.Context, purpose TokenPurpose, tokenHash string) (*Token, error)
+}
+
+type tokensDAOImpl struct {
+	DynamoDBClient *dynamodb.Client
+
+	tableName string
+}
+
+func NewTokensDAO(dynamoDBClient *dynamodb.Client, tables Tables) TokensDAO {
+	return &tokensDAOImpl{
+		DynamoDBClient: dynamoDBClient,
+		tableName:      tables.Table,
+	}
+}
+
+func (dao tokensDAOImpl) CreateToken(ctx context.Context, token Token) error {
+	item, err := attributevalue.MarshalMap(tokenItem{
+		Key:   tokenPrefix + token.TokenHash,
+		TTL:   token.ExpiresAt.Unix(),
+		Token: token,
+	})
+	if err != nil {
+		return err
+	}
+
+	cond := expression.AttributeNotExists(expression.Name("userID"))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.DynamoDBClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(dao.tableName),
+		Item:                     item,
+		ConditionExpression:      expr.Condition(),
+		ExpressionAttributeNames: expr.Names(),
+	})
+	return dynamoDBError(err)
+}
+
+func (dao tokensDAOImpl) ConsumeToken(ctx context.Context, purpose TokenPurpose, tokenHash string) (*Token, error) {
+	cond := expression.Name("purpose").Equal(expression.Value(purpose))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	deleteItemOutput, err := dao.DynamoDBClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		Key:                       tokenKey(tokenHash),
+		TableName:                 aws.String(dao.tableName),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ReturnValues:              types.ReturnValueAllOld,
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return nil, errTokenNotFound
+		}
+		return nil, dynamoDBError(err)
+	}
+
+	if deleteItemOutput.Attributes == nil {
+		return nil, errTokenNotFound
+	}
+
+	item := &tokenItem{}
+	err = attributevalue.UnmarshalMap(deleteItemOutput.Attributes, item)
+	if err != nil {
+		return nil, corruptRecordError(err)
+	}
+
+	return &item.Token, nil
+}
@@ -0,0 +1,39 @@
+package daos
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// failedCondition returns the index of the first transaction item whose condition
+// failed if err is a cancelled transaction.
+func failedCondition(err error) (int, bool) {
+	var cancelled *types.TransactionCanceledException
+	if !errors.As(err, &cancelled) {
+		return 0, false
+	}
+
+	for i, reason := range cancelled.CancellationReasons {
+		if aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// transactionError converts a cancelled transaction into the error matching the first
+// failed condition. failures maps the index of each transaction item to the error
+// reported when its condition fails.
+func transactionError(err error, failures map[int]error) error {
+	if i, ok := failedCondition(err); ok {
+		if failure, ok := failures[i]; ok {
+			return failure
+		}
+		return dynamoDBError(fmt.Errorf("transaction cancelled: %w", err))
+	}
+
+	return dynamoDBError(err)
+}
@@ -2,175 +2,822 @@ package daos
 
 import (
 	"context"
+	"errors"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/google/uuid"
-	"github.com/raidcomp/users-service/auth"
-	"log"
 	"time"
 )
 
 type User struct {
-	UserID         string    `dynamodbav:"userID"`
-	Login          string    `dynamodbav:"login"`
-	Email          string    `dynamodbav:"email"`
+	UserID string `dynamodbav:"userID"`
+	Login  string `dynamodbav:"login"`
+	Email  string `dynamodbav:"email"`
+	// CanonicalLogin and CanonicalEmail are the forms of Login and Email that are kept
+	// unique and looked up, see CanonicalLogin and CanonicalEmail.
+	CanonicalLogin string    `dynamodbav:"canonicalLogin"`
+	CanonicalEmail string    `dynamodbav:"canonicalEmail"`
+	EmailVerified  bool      `dynamodbav:"emailVerified"`
 	HashedPassword string    `dynamodbav:"hashed_password"`
 	CreatedAt      time.Time `dynamodbav:"createdAt"`
 	UpdatedAt      time.Time `dynamodbav:"updatedAt"`
+	// DeletedAt is set on soft-deleted users. These tombstones are never returned
+	// by the Get methods but keep their login and email reserved until purged.
+	DeletedAt *time.Time `dynamodbav:"deletedAt,omitempty"`
+	// Failed password checks since the last successful one, and until when the account
+	// is locked because of them.
+	FailedLoginAttempts int        `dynamodbav:"failedLoginAttempts,omitempty"`
+	LastFailedLoginAt   *time.Time `dynamodbav:"lastFailedLoginAt,omitempty"`
+	LockedUntil         *time.Time `dynamodbav:"lockedUntil,omitempty"`
+	// Sessions created before SessionsRevokedAt can no longer be refreshed.
+	SessionsRevokedAt *time.Time `dynamodbav:"sessionsRevokedAt,omitempty"`
+	// TOTPSecret is the encrypted TOTP secret, pending until TOTPEnabled is set by
+	// confirming enrollment. TOTPLastStep is the time step of the last accepted code.
+	TOTPSecret   string `dynamodbav:"totpSecret,omitempty"`
+	TOTPEnabled  bool   `dynamodbav:"totpEnabled,omitempty"`
+	TOTPLastStep int64  `dynamodbav:"totpLastStep,omitempty"`
+	// RecoveryCodeHashes holds the hashes of the unused recovery codes as keys, so a code
+	// can be consumed atomically by removing its key.
+	RecoveryCodeHashes map[string]bool `dynamodbav:"recoveryCodeHashes,omitempty"`
+	// WebAuthnCredentialIDs are the IDs of the user's WebAuthn credentials, stored as
+	// separate items.
+	WebAuthnCredentialIDs []string `dynamodbav:"webAuthnCredentialIDs,omitempty"`
+	// ExternalIdentities are the accounts at identity providers the user can sign in with.
+	// Users created through one may have no password.
+	ExternalIdentities []ExternalIdentity `dynamodbav:"externalIdentities,omitempty"`
+	// LoginSearchShard is the LoginPrefixIndex hash key derived from CanonicalLogin.
+	LoginSearchShard string `dynamodbav:"loginSearchShard,omitempty"`
+	// Roles grant access to other users' data, e.g. admin, moderator or service:<name>.
+	Roles []string `dynamodbav:"roles,omitempty"`
+}
+
+// sortableTimeLayout formats times with a fixed width, which compare chronologically as
+// strings in UTC unlike RFC 3339 with local offsets and trimmed fractional seconds.
+const sortableTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// sortableTime returns t in UTC formatted with sortableTimeLayout. It still parses as
+// RFC 3339 when the attribute is unmarshaled.
+func sortableTime(t time.Time) string {
+	return t.UTC().Format(sortableTimeLayout)
 }
 
 const LOGIN_INDEX = "LoginIndex"
 const EMAIL_INDEX = "EmailIndex"
 
+// Tables names the DynamoDB table users, sessions and tokens are stored in, and its
+// indexes.
+type Tables struct {
+	Table            string
+	LoginIndex       string
+	EmailIndex       string
+	LoginPrefixIndex string
+}
+
+// DefaultTables are the table and indexes created by the terraform configuration.
+var DefaultTables = Tables{
+	Table:            "users",
+	LoginIndex:       LOGIN_INDEX,
+	EmailIndex:       EMAIL_INDEX,
+	LoginPrefixIndex: LOGIN_PREFIX_INDEX,
+}
+
+var (
+	errUserNotFound = notFoundError("user not found")
+	// errTOTPStepUsed is returned by updateUserItem to RecordTOTPStep, which reports it
+	// as false.
+	errTOTPStepUsed = conflictError("TOTP step was already used")
+
+	ErrEmailChanged         = conflictError("email was changed")
+	ErrTOTPEnabled          = conflictError("TOTP is already enabled")
+	ErrTOTPNotEnabled       = conflictError("TOTP is not enabled")
+	ErrRecoveryCodeNotFound = notFoundError("recovery code not found")
+)
+
+// UsersDAO stores users. Missing and soft-deleted users are reported with an error that
+// is ErrNotFound.
 type UsersDAO interface {
-	CreateUser(ctx context.Context, login, email, rawPassword string) (User, error)
+	// CreateUser creates a user with the given external identities linked. hashedPassword
+	// may be empty for users signing in through an external identity.
+	CreateUser(ctx context.Context, login, email, hashedPassword string, identities ...ExternalIdentity) (User, error)
 	GetUserByID(ctx context.Context, id string) (*User, error)
 	GetUserByLogin(ctx context.Context, login string) (*User, error)
+	GetUsersByEmail(ctx context.Context, email string) ([]User, error)
+	UpdateUser(ctx context.Context, id string, update UserUpdate) (*User, error)
+	DeleteUser(ctx context.Context, id string, purge bool) (*User, error)
+	// RecordFailedLogin atomically increments the user's failed login attempts and returns
+	// the updated user.
+	RecordFailedLogin(ctx context.Context, id string) (*User, error)
+	LockUser(ctx context.Context, id string, until time.Time) error
+	// ResetFailedLogins clears the failed login attempts and any lock of the user.
+	ResetFailedLogins(ctx context.Context, id string) error
+	// MarkEmailVerified marks the user's email as verified if it is still email, and
+	// returns the updated user or ErrEmailChanged otherwise.
+	MarkEmailVerified(ctx context.Context, id, email string) (*User, error)
+	// SetPendingTOTPSecret stores a TOTP secret to be confirmed by EnableTOTP, unless TOTP is
+	// already enabled. It returns the updated user or ErrTOTPEnabled otherwise.
+	SetPendingTOTPSecret(ctx context.Context, id, encryptedSecret string) (*User, error)
+	// EnableTOTP enables TOTP if encryptedSecret is still the pending secret, and returns the
+	// updated user or ErrConcurrentModification otherwise.
+	EnableTOTP(ctx context.Context, id, encryptedSecret string, step int64) (*User, error)
+	DisableTOTP(ctx context.Context, id string) (*User, error)
+	// RecordTOTPStep stores step as the last accepted TOTP time step. It returns false if
+	// a code of the same or a later step was already accepted.
+	RecordTOTPStep(ctx context.Context, id string, step int64) (bool, error)
+	// SetRecoveryCodes replaces the user's recovery codes if TOTP is enabled, and returns the
+	// updated user or ErrTOTPNotEnabled otherwise.
+	SetRecoveryCodes(ctx context.Context, id string, codeHashes []string) (*User, error)
+	// ConsumeRecoveryCode removes the recovery code with codeHash and returns the updated
+	// user, or ErrRecoveryCodeNotFound if the user has no such code.
+	ConsumeRecoveryCode(ctx context.Context, id, codeHash string) (*User, error)
+	// SetUserRoles replaces the user's roles and returns the updated user.
+	SetUserRoles(ctx context.Context, id string, roles []string) (*User, error)
+	// AddWebAuthnCredential stores a credential and adds it to its user, returning the
+	// updated user.
+	AddWebAuthnCredential(ctx context.Context, credential WebAuthnCredential) (*User, error)
+	GetWebAuthnCredential(ctx context.Context, credentialID string) (*WebAuthnCredential, error)
+	// UpdateWebAuthnSignCount sets the signature counter of a credential if it is still
+	// oldSignCount, and returns whether it did.
+	UpdateWebAuthnSignCount(ctx context.Context, credentialID string, oldSignCount, newSignCount uint32) (bool, error)
+	// LinkExternalIdentity links an identity to the user and returns the updated user.
+	// Linking an identity that is already linked does nothing.
+	LinkExternalIdentity(ctx context.Context, id string, identity ExternalIdentity) (*User, error)
+	// UnlinkExternalIdentity unlinks the user's identity of provider and returns the updated
+	// user.
+	UnlinkExternalIdentity(ctx context.Context, id string, provider ExternalIdentityProvider) (*User, error)
+	GetUserByExternalIdentity(ctx context.Context, provider ExternalIdentityProvider, subject string) (*User, error)
+	// ListUsers returns up to pageSize users matching filter, in no particular order, along
+	// with the page token of the next page or an empty string on the last page. Pages may
+	// be returned short when few users match.
+	ListUsers(ctx context.Context, filter ListUsersFilter, pageSize int, pageToken string) ([]User, string, error)
+	// BatchGetUsersByID returns the users found for ids, keyed by ID.
+	BatchGetUsersByID(ctx context.Context, ids []string) (map[string]User, error)
+	// BatchGetUsersByLogin returns the users found for logins, keyed by login.
+	BatchGetUsersByLogin(ctx context.Context, logins []string) (map[string]User, error)
+	// SearchByLoginPrefix returns up to limit users whose login starts with prefix, ignoring
+	// case, in login order along with the page token of the next page or an empty string
+	// on the last page.
+	SearchByLoginPrefix(ctx context.Context, prefix string, limit int, pageToken string) ([]User, string, error)
+}
+
+// ListUsersFilter restricts the users returned by ListUsers. Zero fields match all users.
+type ListUsersFilter struct {
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	LoginPrefix   string
+}
+
+// UserUpdate holds the fields to change on a User. Nil fields are left untouched.
+type UserUpdate struct {
+	Login          *string
+	Email          *string
+	HashedPassword *string
+	// RevokeSessions revokes all sessions created before the update.
+	RevokeSessions bool
 }
 
 type usersDAOImpl struct {
 	DynamoDBClient *dynamodb.Client
 
-	tableName string
+	tableName        string
+	loginIndex       string
+	emailIndex       string
+	loginPrefixIndex string
 }
 
-func NewUsersDAO(dynamoDBClient *dynamodb.Client) UsersDAO {
+func NewUsersDAO(dynamoDBClient *dynamodb.Client, tables Tables) UsersDAO {
 	return &usersDAOImpl{
-		DynamoDBClient: dynamoDBClient,
-		tableName:      "users",
+		DynamoDBClient:   dynamoDBClient,
+		tableName:        tables.Table,
+		loginIndex:       tables.LoginIndex,
+		emailIndex:       tables.EmailIndex,
+		loginPrefixIndex: tables.LoginPrefixIndex,
 	}
 }
 
-func (dao usersDAOImpl) CreateUser(ctx context.Context, login, email, rawPassword string) (User, error) {
-	hashedPassword, err := auth.HashPassword(rawPassword)
+func (dao usersDAOImpl) CreateUser(ctx context.Context, login, email, hashedPassword string, identities ...ExternalIdentity) (User, error) {
+	err := checkLogin(login)
 	if err != nil {
 		return User{}, err
 	}
 
 	now := time.Now()
 	newUser := User{
-		UserID:         uuid.NewString(),
-		Login:          login,
-		Email:          email,
-		HashedPassword: hashedPassword,
-		CreatedAt:      now,
-		UpdatedAt:      now,
+		UserID:             uuid.NewString(),
+		Login:              login,
+		Email:              email,
+		CanonicalLogin:     CanonicalLogin(login),
+		CanonicalEmail:     CanonicalEmail(email),
+		HashedPassword:     hashedPassword,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		ExternalIdentities: identities,
 	}
+	newUser.LoginSearchShard = loginSearchShard(newUser.CanonicalLogin)
 
 	putItem, err := attributevalue.MarshalMap(newUser)
 	if err != nil {
 		return User{}, err
 	}
+	// ListUsers filters by createdAt, which must compare as a string
+	putItem["createdAt"] = &types.AttributeValueMemberS{Value: sortableTime(now)}
 
-	input := dynamodb.PutItemInput{
-		TableName: &dao.tableName,
-		Item:      putItem,
+	cond := expression.AttributeNotExists(expression.Name("userID"))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return User{}, err
 	}
 
-	_, err = dao.DynamoDBClient.PutItem(ctx, &input)
+	putLogin, err := dao.putReservation(loginReservationKey(newUser.CanonicalLogin), newUser.UserID)
 	if err != nil {
 		return User{}, err
 	}
 
+	putEmail, err := dao.putReservation(emailReservationKey(newUser.CanonicalEmail), newUser.UserID)
+	if err != nil {
+		return User{}, err
+	}
+
+	transactItems := []types.TransactWriteItem{
+		{
+			Put: &types.Put{
+				TableName:                aws.String(dao.tableName),
+				Item:                     putItem,
+				ConditionExpression:      expr.Condition(),
+				ExpressionAttributeNames: expr.Names(),
+			},
+		},
+		putLogin,
+		putEmail,
+	}
+	failures := map[int]error{
+		1: ErrLoginTaken,
+		2: ErrEmailTaken,
+	}
+	for _, identity := range identities {
+		putIdentity, err := dao.putReservation(identityReservationKey(identity.Provider, identity.Subject), newUser.UserID)
+		if err != nil {
+			return User{}, err
+		}
+		failures[len(transactItems)] = ErrExternalIdentityTaken
+		transactItems = append(transactItems, putIdentity)
+	}
+
+	_, err = dao.DynamoDBClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err != nil {
+		return User{}, transactionError(err, failures)
+	}
+
 	return newUser, nil
 }
 
 func (dao usersDAOImpl) GetUserByID(ctx context.Context, id string) (*User, error) {
+	user, err := dao.getUserItem(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	return user, nil
+}
+
+// getUserItem returns the user item for id, including soft-deleted users.
+func (dao usersDAOImpl) getUserItem(ctx context.Context, id string, consistentRead bool) (*User, error) {
 	getItemOutput, err := dao.DynamoDBClient.GetItem(ctx, &dynamodb.GetItemInput{
 		Key: map[string]types.AttributeValue{
 			"userID": &types.AttributeValueMemberS{Value: id},
 		},
-		TableName: aws.String(dao.tableName),
+		TableName:      aws.String(dao.tableName),
+		ConsistentRead: aws.Bool(consistentRead),
 	})
 	if err != nil {
-		return &User{}, err
+		return nil, dynamoDBError(err)
 	}
 
 	if getItemOutput.Item == nil {
-		return nil, nil
+		return nil, errUserNotFound
 	}
 
 	user := &User{}
 	err = attributevalue.UnmarshalMap(getItemOutput.Item, user)
 	if err != nil {
-		log.Panicf("unmarshal failed, %v", err)
+		return nil, corruptRecordError(err)
+	}
+
+	// Items stored next to the users, e.g. reservations, sessions and tokens, have no login
+	if user.Login == "" {
+		return nil, errUserNotFound
 	}
 
 	return user, nil
 }
 
 func (dao usersDAOImpl) GetUserByLogin(ctx context.Context, login string) (*User, error) {
-	cond := expression.Name("login").Equal(expression.Value(login))
+	cond := expression.Name("canonicalLogin").Equal(expression.Value(CanonicalLogin(login)))
 	expr, err := expression.NewBuilder().WithCondition(cond).Build()
 	if err != nil {
-		log.Panicf("error creating expression, %v", err)
+		return nil, err
 	}
 
 	queryOutput, err := dao.DynamoDBClient.Query(ctx, &dynamodb.QueryInput{
 		TableName:                 aws.String(dao.tableName),
-		IndexName:                 aws.String(LOGIN_INDEX),
+		IndexName:                 aws.String(dao.loginIndex),
 		KeyConditionExpression:    expr.Condition(),
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 		Limit:                     aws.Int32(1),
 	})
 	if err != nil {
-		return nil, err
-	}
-
-	if queryOutput.Items == nil {
-		return nil, nil
+		return nil, dynamoDBError(err)
 	}
 
 	var users []User
 	err = attributevalue.UnmarshalListOfMaps(queryOutput.Items, &users)
 	if err != nil {
-		log.Panicf("unmarshal failed, %v", err)
+		return nil, corruptRecordError(err)
 	}
 
-	if len(users) == 0 {
-		return nil, nil
+	if len(users) == 0 || users[0].DeletedAt != nil {
+		return nil, errUserNotFound
 	}
 
 	return &users[0], nil
 }
 
-func (dao usersDAOImpl) GetUsersByEmail(ctx context.Context, email string) ([]User, error) {
-	filter := expression.Name("email").Equal(expression.Value(email))
-	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+func (dao usersDAOImpl) UpdateUser(ctx context.Context, id string, update UserUpdate) (*User, error) {
+	// Reservations need the current login and email to be released, and guard
+	// against them changing underneath us
+	user, err := dao.getUserItem(ctx, id, true)
 	if err != nil {
-		log.Panicf("error creating expression, %v", err)
+		return nil, err
 	}
 
-	queryOutput, err := dao.DynamoDBClient.Query(ctx, &dynamodb.QueryInput{
+	if user.DeletedAt != nil {
+		return nil, errUserNotFound
+	}
+
+	now := time.Now()
+	updateExpr := expression.Set(expression.Name("updatedAt"), expression.Value(now))
+	cond := expression.AttributeExists(expression.Name("userID")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt"))).
+		And(expression.Name("login").Equal(expression.Value(user.Login))).
+		And(expression.Name("email").Equal(expression.Value(user.Email)))
+
+	// Logins and emails changed to another canonical form move their reservation, while
+	// changes of case only update the displayed form
+	var reservationItems []types.TransactWriteItem
+	failures := map[int]error{0: ErrConcurrentModification}
+	if update.Login != nil && *update.Login != user.Login {
+		err = checkLogin(*update.Login)
+		if err != nil {
+			return nil, err
+		}
+		updateExpr = updateExpr.Set(expression.Name("login"), expression.Value(*update.Login))
+	}
+	if update.Login != nil && CanonicalLogin(*update.Login) != CanonicalLogin(user.Login) {
+		canonicalLogin := CanonicalLogin(*update.Login)
+		user.CanonicalLogin = canonicalLogin
+		user.LoginSearchShard = loginSearchShard(canonicalLogin)
+		updateExpr = updateExpr.Set(expression.Name("canonicalLogin"), expression.Value(canonicalLogin)).
+			Set(expression.Name("loginSearchShard"), expression.Value(user.LoginSearchShard))
+
+		putLogin, err := dao.putReservation(loginReservationKey(canonicalLogin), id)
+		if err != nil {
+			return nil, err
+		}
+		deleteLogin, err := dao.deleteReservation(loginReservationKey(CanonicalLogin(user.Login)), id)
+		if err != nil {
+			return nil, err
+		}
+		// Transaction items are offset by one for the user update itself
+		failures[len(reservationItems)+1] = ErrLoginTaken
+		reservationItems = append(reservationItems, putLogin)
+		failures[len(reservationItems)+1] = ErrConcurrentModification
+		reservationItems = append(reservationItems, deleteLogin)
+	}
+	if update.Email != nil && *update.Email != user.Email {
+		updateExpr = updateExpr.Set(expression.Name("email"), expression.Value(*update.Email))
+	}
+	if update.Email != nil && CanonicalEmail(*update.Email) != CanonicalEmail(user.Email) {
+		canonicalEmail := CanonicalEmail(*update.Email)
+		user.CanonicalEmail = canonicalEmail
+		updateExpr = updateExpr.Set(expression.Name("canonicalEmail"), expression.Value(canonicalEmail)).
+			Set(expression.Name("emailVerified"), expression.Value(false))
+		user.EmailVerified = false
+
+		putEmail, err := dao.putReservation(emailReservationKey(canonicalEmail), id)
+		if err != nil {
+			return nil, err
+		}
+		deleteEmail, err := dao.deleteReservation(emailReservationKey(CanonicalEmail(user.Email)), id)
+		if err != nil {
+			return nil, err
+		}
+		// Transaction items are offset by one for the user update itself
+		failures[len(reservationItems)+1] = ErrEmailTaken
+		reservationItems = append(reservationItems, putEmail)
+		failures[len(reservationItems)+1] = ErrConcurrentModification
+		reservationItems = append(reservationItems, deleteEmail)
+	}
+	if update.HashedPassword != nil {
+		updateExpr = updateExpr.Set(expression.Name("hashed_password"), expression.Value(*update.HashedPassword))
+		user.HashedPassword = *update.HashedPassword
+	}
+	if update.RevokeSessions {
+		updateExpr = updateExpr.Set(expression.Name("sessionsRevokedAt"), expression.Value(now))
+		user.SessionsRevokedAt = &now
+	}
+
+	expr, err := expression.NewBuilder().WithUpdate(updateExpr).WithCondition(cond).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	transactItems := append([]types.TransactWriteItem{
+		{
+			Update: &types.Update{
+				Key: map[string]types.AttributeValue{
+					"userID": &types.AttributeValueMemberS{Value: id},
+				},
+				TableName:                 aws.String(dao.tableName),
+				UpdateExpression:          expr.Update(),
+				ConditionExpression:       expr.Condition(),
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+			},
+		},
+	}, reservationItems...)
+
+	_, err = dao.DynamoDBClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err != nil {
+		return nil, transactionError(err, failures)
+	}
+
+	if update.Login != nil {
+		user.Login = *update.Login
+	}
+	if update.Email != nil {
+		user.Email = *update.Email
+	}
+	user.UpdatedAt = now
+
+	return user, nil
+}
+
+func (dao usersDAOImpl) DeleteUser(ctx context.Context, id string, purge bool) (*User, error) {
+	if purge {
+		return dao.purgeUser(ctx, id)
+	}
+
+	now := time.Now()
+	updateExpr := expression.Set(expression.Name("deletedAt"), expression.Value(now)).
+		Set(expression.Name("updatedAt"), expression.Value(now))
+	cond := expression.AttributeExists(expression.Name("userID")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt")))
+
+	return dao.updateUserItem(ctx, id, updateExpr, cond, errUserNotFound)
+}
+
+// purgeUser permanently removes the user item, soft-deleted or not, along with its
+// login, email and external identity reservations so they can be used again, and its
+// WebAuthn credentials.
+func (dao usersDAOImpl) purgeUser(ctx context.Context, id string) (*User, error) {
+	user, err := dao.getUserItem(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Credentials and identities added meanwhile would be left behind
+	cond := expression.Name("login").Equal(expression.Value(user.Login)).
+		And(expression.Name("email").Equal(expression.Value(user.Email))).
+		And(sizeEquals("webAuthnCredentialIDs", len(user.WebAuthnCredentialIDs))).
+		And(sizeEquals("externalIdentities", len(user.ExternalIdentities)))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	deleteLogin, err := dao.deleteReservation(loginReservationKey(CanonicalLogin(user.Login)), id)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteEmail, err := dao.deleteReservation(emailReservationKey(CanonicalEmail(user.Email)), id)
+	if err != nil {
+		return nil, err
+	}
+
+	transactItems := []types.TransactWriteItem{
+		{
+			Delete: &types.Delete{
+				Key: map[string]types.AttributeValue{
+					"userID": &types.AttributeValueMemberS{Value: id},
+				},
+				TableName:                 aws.String(dao.tableName),
+				ConditionExpression:       expr.Condition(),
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+			},
+		},
+		deleteLogin,
+		deleteEmail,
+	}
+	transactItems = append(transactItems, dao.deleteWebAuthnCredentials(*user)...)
+	for _, identity := range user.ExternalIdentities {
+		deleteIdentity, err := dao.deleteReservation(identityReservationKey(identity.Provider, identity.Subject), id)
+		if err != nil {
+			return nil, err
+		}
+		transactItems = append(transactItems, deleteIdentity)
+	}
+
+	_, err = dao.DynamoDBClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err != nil {
+		if _, ok := failedCondition(err); ok {
+			return nil, ErrConcurrentModification
+		}
+		return nil, dynamoDBError(err)
+	}
+
+	return user, nil
+}
+
+// sizeEquals is a condition on the number of elements of a list attribute, which is
+// omitted when empty.
+func sizeEquals(name string, size int) expression.ConditionBuilder {
+	if size == 0 {
+		return expression.AttributeNotExists(expression.Name(name))
+	}
+	return expression.Size(expression.Name(name)).Equal(expression.Value(size))
+}
+
+func (dao usersDAOImpl) RecordFailedLogin(ctx context.Context, id string) (*User, error) {
+	updateExpr := expression.Add(expression.Name("failedLoginAttempts"), expression.Value(1)).
+		Set(expression.Name("lastFailedLoginAt"), expression.Value(time.Now()))
+	cond := expression.AttributeExists(expression.Name("userID")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt")))
+
+	return dao.updateUserItem(ctx, id, updateExpr, cond, errUserNotFound)
+}
+
+func (dao usersDAOImpl) LockUser(ctx context.Context, id string, until time.Time) error {
+	updateExpr := expression.Set(expression.Name("lockedUntil"), expression.Value(until))
+	cond := expression.AttributeExists(expression.Name("userID")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt")))
+
+	_, err := dao.updateUserItem(ctx, id, updateExpr, cond, errUserNotFound)
+	return err
+}
+
+func (dao usersDAOImpl) ResetFailedLogins(ctx context.Context, id string) error {
+	updateExpr := expression.Remove(expression.Name("failedLoginAttempts")).
+		Remove(expression.Name("lastFailedLoginAt")).
+		Remove(expression.Name("lockedUntil"))
+	cond := expression.AttributeExists(expression.Name("userID")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt")))
+
+	_, err := dao.updateUserItem(ctx, id, updateExpr, cond, errUserNotFound)
+	return err
+}
+
+func (dao usersDAOImpl) MarkEmailVerified(ctx context.Context, id, email string) (*User, error) {
+	updateExpr := expression.Set(expression.Name("emailVerified"), expression.Value(true)).
+		Set(expression.Name("updatedAt"), expression.Value(time.Now()))
+	cond := expression.AttributeExists(expression.Name("userID")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt"))).
+		And(expression.Name("canonicalEmail").Equal(expression.Value(CanonicalEmail(email))))
+
+	return dao.updateUserItem(ctx, id, updateExpr, cond, ErrEmailChanged)
+}
+
+func (dao usersDAOImpl) SetPendingTOTPSecret(ctx context.Context, id, encryptedSecret string) (*User, error) {
+	updateExpr := expression.Set(expression.Name("totpSecret"), expression.Value(encryptedSecret)).
+		Set(expression.Name("updatedAt"), expression.Value(time.Now()))
+	cond := expression.AttributeExists(expression.Name("userID")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt"))).
+		And(expression.AttributeNotExists(expression.Name("totpEnabled")))
+
+	return dao.updateUserItem(ctx, id, updateExpr, cond, ErrTOTPEnabled)
+}
+
+func (dao usersDAOImpl) EnableTOTP(ctx context.Context, id, encryptedSecret string, step int64) (*User, error) {
+	updateExpr := expression.Set(expression.Name("totpEnabled"), expression.Value(true)).
+		Set(expression.Name("totpLastStep"), expression.Value(step)).
+		Set(expression.Name("updatedAt"), expression.Value(time.Now()))
+	cond := expression.AttributeExists(expression.Name("userID")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt"))).
+		And(expression.AttributeNotExists(expression.Name("totpEnabled"))).
+		And(expression.Name("totpSecret").Equal(expression.Value(encryptedSecret)))
+
+	return dao.updateUserItem(ctx, id, updateExpr, cond, ErrConcurrentModification)
+}
+
+func (dao usersDAOImpl) DisableTOTP(ctx context.Context, id string) (*User, error) {
+	updateExpr := expression.Remove(expression.Name("totpSecret")).
+		Remove(expression.Name("totpEnabled")).
+		Remove(expression.Name("totpLastStep")).
+		Remove(expression.Name("recoveryCodeHashes")).
+		Set(expression.Name("updatedAt"), expression.Value(time.Now()))
+	cond := expression.AttributeExists(expression.Name("userID")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt")))
+
+	return dao.updateUserItem(ctx, id, updateExpr, cond, errUserNotFound)
+}
+
+func (dao usersDAOImpl) RecordTOTPStep(ctx context.Context, id string, step int64) (bool, error) {
+	updateExpr := expression.Set(expression.Name("totpLastStep"), expression.Value(step))
+	cond := expression.AttributeExists(expression.Name("totpEnabled")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt"))).
+		And(expression.AttributeNotExists(expression.Name("totpLastStep")).
+			Or(expression.Name("totpLastStep").LessThan(expression.Value(step))))
+
+	_, err := dao.updateUserItem(ctx, id, updateExpr, cond, errTOTPStepUsed)
+	if errors.Is(err, errTOTPStepUsed) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (dao usersDAOImpl) SetRecoveryCodes(ctx context.Context, id string, codeHashes []string) (*User, error) {
+	hashes := map[string]bool{}
+	for _, codeHash := range codeHashes {
+		hashes[codeHash] = true
+	}
+
+	updateExpr := expression.Set(expression.Name("recoveryCodeHashes"), expression.Value(hashes)).
+		Set(expression.Name("updatedAt"), expression.Value(time.Now()))
+	cond := expression.AttributeExists(expression.Name("totpEnabled")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt")))
+
+	return dao.updateUserItem(ctx, id, updateExpr, cond, ErrTOTPNotEnabled)
+}
+
+func (dao usersDAOImpl) ConsumeRecoveryCode(ctx context.Context, id, codeHash string) (*User, error) {
+	// Hashes are hex so they are safe to use in a document path
+	codePath := expression.Name("recoveryCodeHashes." + codeHash)
+	updateExpr := expression.Remove(codePath).
+		Set(expression.Name("updatedAt"), expression.Value(time.Now()))
+	cond := expression.AttributeExists(codePath).
+		And(expression.AttributeNotExists(expression.Name("deletedAt")))
+
+	return dao.updateUserItem(ctx, id, updateExpr, cond, ErrRecoveryCodeNotFound)
+}
+
+func (dao usersDAOImpl) SetUserRoles(ctx context.Context, id string, roles []string) (*User, error) {
+	var updateExpr expression.UpdateBuilder
+	if len(roles) == 0 {
+		updateExpr = expression.Remove(expression.Name("roles"))
+	} else {
+		updateExpr = expression.Set(expression.Name("roles"), expression.Value(roles))
+	}
+	updateExpr = updateExpr.Set(expression.Name("updatedAt"), expression.Value(time.Now()))
+	cond := expression.AttributeExists(expression.Name("userID")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt")))
+
+	return dao.updateUserItem(ctx, id, updateExpr, cond, errUserNotFound)
+}
+
+// updateUserItem applies updateExpr to the user item if cond holds and returns the
+// updated user, or failure if cond failed.
+func (dao usersDAOImpl) updateUserItem(ctx context.Context, id string, updateExpr expression.UpdateBuilder, cond expression.ConditionBuilder, failure error) (*User, error) {
+	expr, err := expression.NewBuilder().WithUpdate(updateExpr).WithCondition(cond).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	updateItemOutput, err := dao.DynamoDBClient.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		Key: map[string]types.AttributeValue{
+			"userID": &types.AttributeValueMemberS{Value: id},
+		},
 		TableName:                 aws.String(dao.tableName),
-		IndexName:                 aws.String(EMAIL_INDEX),
-		KeyConditionExpression:    expr.KeyCondition(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
-		FilterExpression:          expr.Filter(),
-		ProjectionExpression:      expr.Projection(),
+		ReturnValues:              types.ReturnValueAllNew,
 	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return nil, failure
+		}
+		return nil, dynamoDBError(err)
+	}
+
+	user := &User{}
+	err = attributevalue.UnmarshalMap(updateItemOutput.Attributes, user)
+	if err != nil {
+		return nil, corruptRecordError(err)
+	}
+
+	return user, nil
+}
+
+func (dao usersDAOImpl) GetUsersByEmail(ctx context.Context, email string) ([]User, error) {
+	keyCond := expression.Key("canonicalEmail").Equal(expression.Value(CanonicalEmail(email)))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
 	if err != nil {
 		return nil, err
 	}
 
-	if queryOutput.Items == nil {
-		return nil, nil
+	queryOutput, err := dao.DynamoDBClient.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(dao.tableName),
+		IndexName:                 aws.String(dao.emailIndex),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		return nil, dynamoDBError(err)
 	}
 
 	var users []User
 	err = attributevalue.UnmarshalListOfMaps(queryOutput.Items, &users)
 	if err != nil {
-		log.Panicf("unmarshal failed, %v", err)
+		return nil, corruptRecordError(err)
 	}
 
-	if len(users) == 0 {
+	liveUsers := users[:0]
+	for _, user := range users {
+		if user.DeletedAt == nil {
+			liveUsers = append(liveUsers, user)
+		}
+	}
+
+	if len(liveUsers) == 0 {
 		return nil, nil
 	}
 
-	return users, nil
+	return liveUsers, nil
+}
+
+func (dao usersDAOImpl) ListUsers(ctx context.Context, filter ListUsersFilter, pageSize int, pageToken string) ([]User, string, error) {
+	exclusiveStartKey, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Reservations, sessions and other items stored next to the users have no login
+	cond := expression.AttributeExists(expression.Name("login")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt")))
+	if filter.CreatedAfter != nil {
+		cond = cond.And(expression.Name("createdAt").GreaterThanEqual(expression.Value(sortableTime(*filter.CreatedAfter))))
+	}
+	if filter.CreatedBefore != nil {
+		cond = cond.And(expression.Name("createdAt").LessThan(expression.Value(sortableTime(*filter.CreatedBefore))))
+	}
+	if filter.LoginPrefix != "" {
+		cond = cond.And(expression.Name("canonicalLogin").BeginsWith(CanonicalLogin(filter.LoginPrefix)))
+	}
+	expr, err := expression.NewBuilder().WithFilter(cond).Build()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Limit caps the items read rather than returned, so reading no more than the users
+	// still missing ensures the last evaluated key never skips a matching user
+	var users []User
+	for i := 0; i < maxScansPerPage && len(users) < pageSize; i++ {
+		scanOutput, err := dao.DynamoDBClient.Scan(ctx, &dynamodb.ScanInput{
+			TableName:                 aws.String(dao.tableName),
+			FilterExpression:          expr.Filter(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			ExclusiveStartKey:         exclusiveStartKey,
+			Limit:                     aws.Int32(int32(pageSize - len(users))),
+		})
+		if err != nil {
+			return nil, "", dynamoDBError(err)
+		}
+
+		var pageUsers []User
+		err = attributevalue.UnmarshalListOfMaps(scanOutput.Items, &pageUsers)
+		if err != nil {
+			return nil, "", corruptRecordError(err)
+		}
+		users = append(users, pageUsers...)
+
+		exclusiveStartKey = scanOutput.LastEvaluatedKey
+		if exclusiveStartKey == nil {
+			break
+		}
+	}
+
+	nextPageToken, err := encodePageToken(exclusiveStartKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return users, nextPageToken, nil
 }
@@ -0,0 +1,42 @@
+package daos
+
+import (
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSortableTimeSortsChronologically(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 5, 0, time.UTC)
+	plus2 := time.FixedZone("UTC+2", 2*60*60)
+	// RFC 3339 strings of these sort differently from the times
+	times := []time.Time{
+		base,
+		base.Add(120 * time.Millisecond),
+		base.Add(123 * time.Millisecond),
+		base.Add(time.Second).In(plus2),
+		base.Add(time.Hour).In(time.FixedZone("UTC-5", -5*60*60)),
+		base.Add(2 * time.Hour).In(plus2),
+	}
+
+	formatted := make([]string, len(times))
+	for i, t := range times {
+		formatted[i] = sortableTime(t)
+	}
+
+	if !sort.StringsAreSorted(formatted) {
+		t.Errorf("got %v, want them sorted like the times", formatted)
+	}
+}
+
+func TestSortableTimeUnmarshals(t *testing.T) {
+	want := time.Date(2024, 3, 1, 12, 0, 5, 120000000, time.FixedZone("UTC+2", 2*60*60))
+
+	var got time.Time
+	err := attributevalue.Unmarshal(&types.AttributeValueMemberS{Value: sortableTime(want)}, &got)
+	if err != nil || !got.Equal(want) {
+		t.Errorf("got %v (err: %v), want %v", got, err, want)
+	}
+}
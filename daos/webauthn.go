@@ -0,0 +1,189 @@
+package daos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"time"
+)
+
+// WebAuthn credentials are stored in the users table keyed by WEBAUTHN#<credentialID>
+// and point back to their user through ownerID. The user item lists the IDs of its
+// credentials, which keeps the number of credentials bounded and lets them be found
+// without an index.
+const (
+	webAuthnCredentialPrefix = "WEBAUTHN#"
+	MaxWebAuthnCredentials   = 10
+)
+
+var (
+	ErrWebAuthnCredentialTaken    = conflictError("credential is already registered")
+	ErrTooManyWebAuthnCredentials = failedPreconditionError(fmt.Sprintf("user already has %d credentials", MaxWebAuthnCredentials))
+
+	errWebAuthnCredentialNotFound = notFoundError("credential not found")
+)
+
+// WebAuthnCredential is a passkey registered by a user. CredentialID is the base64url
+// encoded credential ID.
+type WebAuthnCredential struct {
+	CredentialID string `dynamodbav:"credentialID"`
+	UserID       string `dynamodbav:"ownerID"`
+	Name         string `dynamodbav:"name,omitempty"`
+	// PublicKey is the COSE encoded public key of the credential.
+	PublicKey  []byte     `dynamodbav:"publicKey"`
+	SignCount  uint32     `dynamodbav:"signCount"`
+	CreatedAt  time.Time  `dynamodbav:"createdAt"`
+	LastUsedAt *time.Time `dynamodbav:"lastUsedAt,omitempty"`
+}
+
+type webAuthnCredentialItem struct {
+	Key string `dynamodbav:"userID"`
+	WebAuthnCredential
+}
+
+func webAuthnCredentialKey(credentialID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"userID": &types.AttributeValueMemberS{Value: webAuthnCredentialPrefix + credentialID},
+	}
+}
+
+func (dao usersDAOImpl) AddWebAuthnCredential(ctx context.Context, credential WebAuthnCredential) (*User, error) {
+	item, err := attributevalue.MarshalMap(webAuthnCredentialItem{
+		Key:                webAuthnCredentialPrefix + credential.CredentialID,
+		WebAuthnCredential: credential,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	putCond := expression.AttributeNotExists(expression.Name("userID"))
+	putExpr, err := expression.NewBuilder().WithCondition(putCond).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	credentialIDs := expression.Name("webAuthnCredentialIDs")
+	updateExpr := expression.Set(credentialIDs, expression.ListAppend(
+		expression.IfNotExists(credentialIDs, expression.Value([]string{})),
+		expression.Value([]string{credential.CredentialID}),
+	)).Set(expression.Name("updatedAt"), expression.Value(time.Now()))
+	updateCond := expression.AttributeExists(expression.Name("userID")).
+		And(expression.AttributeNotExists(expression.Name("deletedAt"))).
+		And(expression.AttributeNotExists(credentialIDs).
+			Or(expression.Size(credentialIDs).LessThan(expression.Value(MaxWebAuthnCredentials))))
+	updateExprs, err := expression.NewBuilder().WithUpdate(updateExpr).WithCondition(updateCond).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = dao.DynamoDBClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Update: &types.Update{
+					Key: map[string]types.AttributeValue{
+						"userID": &types.AttributeValueMemberS{Value: credential.UserID},
+					},
+					TableName:                 aws.String(dao.tableName),
+					UpdateExpression:          updateExprs.Update(),
+					ConditionExpression:       updateExprs.Condition(),
+					ExpressionAttributeNames:  updateExprs.Names(),
+					ExpressionAttributeValues: updateExprs.Values(),
+				},
+			},
+			{
+				Put: &types.Put{
+					TableName:                aws.String(dao.tableName),
+					Item:                     item,
+					ConditionExpression:      putExpr.Condition(),
+					ExpressionAttributeNames: putExpr.Names(),
+				},
+			},
+		},
+	})
+	if i, ok := failedCondition(err); ok && i == 0 {
+		// The user is either gone or already has the maximum number of credentials
+		user, err := dao.getUserItem(ctx, credential.UserID, true)
+		if err != nil {
+			return nil, err
+		}
+		if user.DeletedAt != nil {
+			return nil, errUserNotFound
+		}
+		return nil, ErrTooManyWebAuthnCredentials
+	} else if err != nil {
+		return nil, transactionError(err, map[int]error{
+			1: ErrWebAuthnCredentialTaken,
+		})
+	}
+
+	return dao.getUserItem(ctx, credential.UserID, true)
+}
+
+func (dao usersDAOImpl) GetWebAuthnCredential(ctx context.Context, credentialID string) (*WebAuthnCredential, error) {
+	getItemOutput, err := dao.DynamoDBClient.GetItem(ctx, &dynamodb.GetItemInput{
+		Key:       webAuthnCredentialKey(credentialID),
+		TableName: aws.String(dao.tableName),
+	})
+	if err != nil {
+		return nil, dynamoDBError(err)
+	}
+
+	if getItemOutput.Item == nil {
+		return nil, errWebAuthnCredentialNotFound
+	}
+
+	item := &webAuthnCredentialItem{}
+	err = attributevalue.UnmarshalMap(getItemOutput.Item, item)
+	if err != nil {
+		return nil, corruptRecordError(err)
+	}
+
+	return &item.WebAuthnCredential, nil
+}
+
+func (dao usersDAOImpl) UpdateWebAuthnSignCount(ctx context.Context, credentialID string, oldSignCount, newSignCount uint32) (bool, error) {
+	updateExpr := expression.Set(expression.Name("signCount"), expression.Value(newSignCount)).
+		Set(expression.Name("lastUsedAt"), expression.Value(time.Now()))
+	cond := expression.Name("signCount").Equal(expression.Value(oldSignCount))
+	expr, err := expression.NewBuilder().WithUpdate(updateExpr).WithCondition(cond).Build()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = dao.DynamoDBClient.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		Key:                       webAuthnCredentialKey(credentialID),
+		TableName:                 aws.String(dao.tableName),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return false, nil
+		}
+		return false, dynamoDBError(err)
+	}
+
+	return true, nil
+}
+
+// deleteWebAuthnCredentials deletes the credentials of a user being purged.
+func (dao usersDAOImpl) deleteWebAuthnCredentials(user User) []types.TransactWriteItem {
+	var deletes []types.TransactWriteItem
+	for _, credentialID := range user.WebAuthnCredentialIDs {
+		deletes = append(deletes, types.TransactWriteItem{
+			Delete: &types.Delete{
+				Key:       webAuthnCredentialKey(credentialID),
+				TableName: aws.String(dao.tableName),
+			},
+		})
+	}
+	return deletes
+}
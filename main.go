@@ -2,38 +2,250 @@ package main
 
 import (
 	"context"
-	"fmt"
-	"github.com/aws/aws-sdk-go-v2/config"
+	"encoding/base64"
+	"errors"
+	"flag"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/raidcomp/users-service/auth"
 	"github.com/raidcomp/users-service/clients"
+	"github.com/raidcomp/users-service/config"
 	"github.com/raidcomp/users-service/daos"
+	"github.com/raidcomp/users-service/policy"
 	pb "github.com/raidcomp/users-service/proto"
 	"github.com/raidcomp/users-service/server"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+// loadSigningKeys loads the access token signing keys from dir, or generates a key for
+// local development when dir is empty, which the config only allows with memory storage.
+func loadSigningKeys(dir, activeKeyID string) (*auth.KeySet, error) {
+	if dir == "" {
+		log.Printf("No signing keys directory set, signing access tokens with a generated key")
+		return auth.NewEphemeralKeySet()
+	}
+
+	return auth.LoadKeySet(dir, activeKeyID)
+}
+
+// loadSecretBox returns a SecretBox encrypting with the base64 encoded key, or a generated
+// key for local development when key is empty, which the config only allows with memory
+// storage.
+func loadSecretBox(key string) (*auth.SecretBox, error) {
+	if key == "" {
+		log.Printf("No secrets key set, encrypting second factor secrets with a generated key")
+		return auth.NewEphemeralSecretBox()
+	}
+
+	decodedKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return auth.NewSecretBox(decodedKey)
+}
+
+// reloadKeysOnSIGHUP reloads the signing keys from disk whenever the process receives SIGHUP.
+func reloadKeysOnSIGHUP(keys *auth.KeySet) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		for range signals {
+			err := keys.Reload()
+			if err != nil {
+				log.Printf("failed to reload signing keys, keeping current keys: %v", err)
+				continue
+			}
+			log.Printf("Reloaded signing keys, signing access tokens with key %s", keys.ActiveKey().ID)
+		}
+	}()
+}
+
+// reloadCertificatesOnChange reloads the TLS certificates every interval when their files
+// were modified, so rotated certificates are picked up without a restart.
+func reloadCertificatesOnChange(certificates *auth.ServerCertificates, interval time.Duration) {
+	go func() {
+		for range time.Tick(interval) {
+			reloaded, err := certificates.ReloadIfChanged()
+			if err != nil {
+				log.Printf("failed to reload TLS certificates, keeping current certificates: %v", err)
+			} else if reloaded {
+				log.Printf("Reloaded TLS certificates")
+			}
+		}
+	}()
+}
+
+// newMailer returns a Mailer appending emails to path, or logging them when path is empty,
+// which the config only allows with memory storage. Emails are never actually sent yet.
+func newMailer(path string) (clients.Mailer, error) {
+	if path == "" {
+		return clients.NewLogMailer(log.Default()), nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return clients.NewLogMailer(log.New(file, "", log.LstdFlags)), nil
+}
+
 func main() {
-	cfg, err := config.LoadDefaultConfig(context.Background())
+	cfg, err := config.Load(os.Args[0], os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	} else if err != nil {
+		log.Fatalf("unable to load config, %v", err)
+	}
+
+	var (
+		usersDAO    daos.UsersDAO
+		sessionsDAO daos.SessionsDAO
+		tokensDAO   daos.TokensDAO
+	)
+	switch cfg.Storage {
+	case "dynamodb":
+		var options []func(*awsconfig.LoadOptions) error
+		if cfg.DynamoDB.Region != "" {
+			options = append(options, awsconfig.WithRegion(cfg.DynamoDB.Region))
+		}
+		awsConfig, err := awsconfig.LoadDefaultConfig(context.Background(), options...)
+		if err != nil {
+			log.Fatalf("unable to load SDK config, %v", err)
+		}
+
+		dynamoDBClient := clients.NewDynamoDBClient(awsConfig, cfg.DynamoDB.Endpoint)
+		tables := cfg.DynamoDB.Tables()
+		usersDAO = daos.NewUsersDAO(dynamoDBClient, tables)
+		sessionsDAO = daos.NewSessionsDAO(dynamoDBClient, tables)
+		tokensDAO = daos.NewTokensDAO(dynamoDBClient, tables)
+	case "memory":
+		log.Printf("Storing users in memory, all data is lost on shutdown")
+		usersDAO = daos.NewInMemoryUsersDAO()
+		sessionsDAO = daos.NewInMemorySessionsDAO()
+		tokensDAO = daos.NewInMemoryTokensDAO()
+	}
+
+	keys, err := loadSigningKeys(cfg.SigningKeys.Dir, cfg.SigningKeys.ActiveKeyID)
+	if err != nil {
+		log.Fatalf("unable to load signing keys, %v", err)
+	}
+	log.Printf("Signing access tokens with key %s", keys.ActiveKey().ID)
+	reloadKeysOnSIGHUP(keys)
+	tokens := auth.NewTokenIssuer(keys)
+	tokens.AccessTokenTTL = cfg.Timeouts.AccessToken
+	tokens.RefreshTokenTTL = cfg.Timeouts.RefreshToken
+
+	argon2idHasher := auth.NewArgon2idHasher(cfg.Passwords.Argon2idParams())
+	bcryptHasher := auth.NewBcryptHasher(cfg.Passwords.BcryptCost)
+
+	// Passwords hashed with the other algorithm are still accepted, and rehashed on login
+	var passwords *auth.PasswordHasher
+	switch cfg.Passwords.Hash {
+	case "argon2id":
+		passwords = auth.NewPasswordHasher(argon2idHasher, bcryptHasher)
+	case "bcrypt":
+		passwords = auth.NewPasswordHasher(bcryptHasher, argon2idHasher)
+	}
+
+	throttle := server.NewLoginThrottle(cfg.Throttle.AccountLockout(), cfg.Throttle.AddressThrottle(), cfg.Throttle.TrustForwardedFor)
+
+	secrets, err := loadSecretBox(cfg.SecretsKey)
+	if err != nil {
+		log.Fatalf("unable to load secrets key, %v", err)
+	}
+
+	mailer, err := newMailer(cfg.MailFile)
 	if err != nil {
-		log.Fatalf("unable to load SDK config, %v", err)
+		log.Fatalf("unable to open mail file, %v", err)
+	}
+
+	loginPolicy := policy.NewLoginPolicy(cfg.Logins.Rules())
+
+	passwordRules := cfg.Passwords.Rules()
+	if cfg.Passwords.BreachedPasswordsFile != "" {
+		breaches, err := policy.OpenBreachCorpus(cfg.Passwords.BreachedPasswordsFile)
+		if err != nil {
+			log.Fatalf("unable to open breached passwords file, %v", err)
+		}
+		defer breaches.Close()
+		passwordRules.Breaches = breaches
+	}
+	passwordPolicy := policy.NewPasswordPolicy(passwordRules)
+
+	tokenTTLs := cfg.Timeouts.TokenTTLs()
+	usersServer := server.NewUsersServer(server.Dependencies{
+		UsersDAO:       usersDAO,
+		SessionsDAO:    sessionsDAO,
+		TokensDAO:      tokensDAO,
+		Tokens:         tokens,
+		Passwords:      passwords,
+		Mailer:         mailer,
+		Secrets:        secrets,
+		WebAuthn:       cfg.WebAuthn.RelyingParty(),
+		Throttle:       throttle,
+		LoginPolicy:    &loginPolicy,
+		PasswordPolicy: &passwordPolicy,
+		TokenTTLs:      &tokenTTLs,
+	})
+
+	var serverOptions []grpc.ServerOption
+	if cfg.TLS.CertFile != "" {
+		certificates, err := auth.LoadServerCertificates(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.ClientCAFile)
+		if err != nil {
+			log.Fatalf("unable to load TLS certificates, %v", err)
+		}
+		reloadCertificatesOnChange(certificates, cfg.TLS.ReloadInterval)
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(certificates.TLSConfig())))
 	}
 
-	dynamoDBClient := clients.NewDynamoDBClient(cfg)
-	usersDAO := daos.NewUsersDAO(dynamoDBClient)
+	var services []server.Service
+	if cfg.TLS.ServicesFile != "" {
+		services, err = server.LoadServices(cfg.TLS.ServicesFile)
+		if err != nil {
+			log.Fatalf("unable to load services, %v", err)
+		}
+	}
 
-	usersServer := server.NewUsersServer(usersDAO)
-	grpcServer := grpc.NewServer()
+	interceptors := []grpc.UnaryServerInterceptor{server.NewTimeoutInterceptor(cfg.Timeouts.Request)}
+	if cfg.SkipAuthorization {
+		log.Printf("Authorization is disabled, any caller can call any RPC")
+	} else {
+		authorizer, err := server.NewAuthorizer(tokens, services)
+		if err != nil {
+			log.Fatalf("invalid services, %v", err)
+		}
+		interceptors = append(interceptors, authorizer.UnaryInterceptor)
+	}
+	serverOptions = append(serverOptions, grpc.ChainUnaryInterceptor(interceptors...))
+	grpcServer := grpc.NewServer(serverOptions...)
 
 	pb.RegisterUsersServer(grpcServer, usersServer)
 
-	port := 5785
-	address := fmt.Sprintf("localhost:%d", port)
-	lis, err := net.Listen("tcp", address)
+	lis, err := net.Listen("tcp", cfg.ListenAddress)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	log.Printf("Listening on %s", address)
+	if cfg.JWKSAddress != "" {
+		go func() {
+			log.Printf("Serving JWKS on %s%s", cfg.JWKSAddress, server.JWKSPath)
+			err := http.ListenAndServe(cfg.JWKSAddress, server.NewJWKSHandler(keys))
+			if err != nil {
+				log.Fatalf("failed to serve JWKS: %v", err)
+			}
+		}()
+	}
+
+	log.Printf("Listening on %s", cfg.ListenAddress)
 	grpcServer.Serve(lis)
 }
@@ -0,0 +1,135 @@
+package policy
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Breached passwords are looked up in a local copy of the Have I Been Pwned password
+// corpus, so passwords never leave the server. The file holds one "<SHA-1>:<count>" line
+// per password, sorted by upper case hex SHA-1 hash, as written by the Pwned Passwords
+// downloader. Like the k-anonymity range API, the range of hashes sharing the first
+// rangePrefixLength characters is found first and then searched for the rest.
+const rangePrefixLength = 5
+
+var ErrInvalidBreachCorpus = errors.New("breach corpus is not a sorted list of <SHA-1>:<count> lines")
+
+// BreachCorpus is a memory-mapped breached password corpus.
+type BreachCorpus struct {
+	data  []byte
+	unmap func() error
+}
+
+// OpenBreachCorpus maps the corpus at path into memory. It must be closed once no longer
+// used.
+func OpenBreachCorpus(path string) (*BreachCorpus, error) {
+	data, unmap, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	corpus := &BreachCorpus{data: data, unmap: unmap}
+	if len(data) > 0 {
+		_, _, ok := parseBreachLine(data[:lineEnd(data, 0)])
+		if !ok {
+			corpus.Close()
+			return nil, ErrInvalidBreachCorpus
+		}
+	}
+
+	return corpus, nil
+}
+
+func (c *BreachCorpus) Close() error {
+	return c.unmap()
+}
+
+// Count returns how many times password was seen in breaches, or 0 if it never was.
+func (c *BreachCorpus) Count(password string) int {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:rangePrefixLength], hash[rangePrefixLength:]
+
+	for start := c.rangeStart(prefix); start < len(c.data); start = lineEnd(c.data, start) + 1 {
+		lineHash, count, ok := parseBreachLine(c.data[start:lineEnd(c.data, start)])
+		if !ok || lineHash[:rangePrefixLength] != prefix {
+			return 0
+		}
+		if lineHash[rangePrefixLength:] == suffix {
+			return count
+		}
+	}
+
+	return 0
+}
+
+// rangeStart binary searches the offset of the first line whose hash starts with prefix
+// or a later one.
+func (c *BreachCorpus) rangeStart(prefix string) int {
+	low, high := 0, len(c.data)
+	for low < high {
+		start := lineStart(c.data, (low+high)/2)
+		end := lineEnd(c.data, start)
+
+		lineHash, _, ok := parseBreachLine(c.data[start:end])
+		if ok && lineHash[:rangePrefixLength] < prefix {
+			low = end + 1
+		} else {
+			high = start
+		}
+	}
+	return low
+}
+
+// lineStart returns the offset of the start of the line containing offset i.
+func lineStart(data []byte, i int) int {
+	return bytes.LastIndexByte(data[:i], '\n') + 1
+}
+
+// lineEnd returns the offset of the newline ending the line starting at start, or the
+// end of data for the last line.
+func lineEnd(data []byte, start int) int {
+	end := bytes.IndexByte(data[start:], '\n')
+	if end < 0 {
+		return len(data)
+	}
+	return start + end
+}
+
+func parseBreachLine(line []byte) (string, int, bool) {
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	hash, count, found := bytes.Cut(line, []byte(":"))
+	if !found || len(hash) != sha1.Size*2 {
+		return "", 0, false
+	}
+
+	n, err := strconv.Atoi(string(count))
+	if err != nil {
+		return "", 0, false
+	}
+
+	return string(hash), n, true
+}
+
+// BreachedPassword forbids passwords seen in breaches at least MinCount times. Padding
+// lines of the corpus, with a count of 0, are never matched.
+type BreachedPassword struct {
+	Corpus   *BreachCorpus
+	MinCount int
+}
+
+func (b BreachedPassword) Check(value string) []string {
+	minCount := b.MinCount
+	if minCount < 1 {
+		minCount = 1
+	}
+
+	if b.Corpus.Count(value) >= minCount {
+		return []string{"has appeared in a data breach and must not be used"}
+	}
+	return nil
+}
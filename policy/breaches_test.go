@@ -0,0 +1,104 @@
+package policy
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// writeBreachCorpus writes a corpus of the given passwords and counts, along with padding
+// lines around their ranges, and returns its path.
+func writeBreachCorpus(t *testing.T, counts map[string]int) string {
+	t.Helper()
+
+	var lines []string
+	for password, count := range counts {
+		sum := sha1.Sum([]byte(password))
+		hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+		lines = append(lines, fmt.Sprintf("%s:%d", hash, count))
+		// Padding of the same range, right before and after the password
+		lines = append(lines, hash[:38]+"00:0", hash[:38]+"FF:0")
+	}
+	lines = append(lines, "00000"+strings.Repeat("0", 35)+":3", "FFFFF"+strings.Repeat("F", 35)+":7")
+	sort.Strings(lines)
+
+	path := filepath.Join(t.TempDir(), "pwned-passwords.txt")
+	err := os.WriteFile(path, []byte(strings.Join(lines, "\r\n")+"\r\n"), 0o600)
+	if err != nil {
+		t.Fatalf("writing corpus: %v", err)
+	}
+	return path
+}
+
+func TestBreachCorpus(t *testing.T) {
+	path := writeBreachCorpus(t, map[string]int{"P4ssw0rd!": 1200, "Raid3r!": 2, "Summer2023!": 1})
+	corpus, err := OpenBreachCorpus(path)
+	if err != nil {
+		t.Fatalf("opening corpus: %v", err)
+	}
+	defer corpus.Close()
+
+	tests := []struct {
+		password string
+		want     int
+	}{
+		{password: "P4ssw0rd!", want: 1200},
+		{password: "Raid3r!", want: 2},
+		{password: "Summer2023!", want: 1},
+		{password: "Raid3r!Password", want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := corpus.Count(tt.password); got != tt.want {
+			t.Errorf("got count %d for %q, want %d", got, tt.password, tt.want)
+		}
+	}
+
+	t.Run("threshold", func(t *testing.T) {
+		rule := BreachedPassword{Corpus: corpus, MinCount: 2}
+		if got := rule.Check("Raid3r!"); len(got) != 1 {
+			t.Errorf("got violations %q for a password seen twice", got)
+		}
+		if got := rule.Check("Summer2023!"); len(got) != 0 {
+			t.Errorf("got violations %q for a password seen once", got)
+		}
+	})
+}
+
+func TestOpenBreachCorpus(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("empty", func(t *testing.T) {
+		path := filepath.Join(dir, "empty.txt")
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatalf("writing corpus: %v", err)
+		}
+
+		corpus, err := OpenBreachCorpus(path)
+		if err != nil {
+			t.Fatalf("opening corpus: %v", err)
+		}
+		defer corpus.Close()
+
+		if got := corpus.Count("P4ssw0rd!"); got != 0 {
+			t.Errorf("got count %d in an empty corpus", got)
+		}
+	})
+
+	t.Run("not a corpus", func(t *testing.T) {
+		path := filepath.Join(dir, "passwords.txt")
+		if err := os.WriteFile(path, []byte("P4ssw0rd!\n"), 0o600); err != nil {
+			t.Fatalf("writing corpus: %v", err)
+		}
+
+		_, err := OpenBreachCorpus(path)
+		if err != ErrInvalidBreachCorpus {
+			t.Errorf("got error %v, want ErrInvalidBreachCorpus", err)
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package policy
+
+import (
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"strings"
+)
+
+// Policy is a set of rules a field of a request must follow, such as logins or passwords.
+type Policy struct {
+	// Field is the request field violations are reported for.
+	Field string
+	Rules []Rule
+}
+
+// Rule is a single requirement on a value.
+type Rule interface {
+	// Check returns a description of each way value breaks the rule, completing
+	// "<field> ...", or nothing if value follows it.
+	Check(value string) []string
+}
+
+// Violation is a way a value breaks a rule of its field's policy.
+type Violation struct {
+	Field       string
+	Description string
+}
+
+func (v Violation) String() string {
+	return v.Field + " " + v.Description
+}
+
+// Violations are all the ways the fields of a request break their policies.
+type Violations []Violation
+
+// Error describes every violation, e.g. "login must be at least 6 characters long,
+// password must contain a number".
+func (v Violations) Error() string {
+	descriptions := make([]string, 0, len(v))
+	for _, violation := range v {
+		descriptions = append(descriptions, violation.String())
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// BadRequest returns the violations as field violations to attach to an InvalidArgument status.
+func (v Violations) BadRequest() *errdetails.BadRequest {
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range v {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.String(),
+		})
+	}
+	return badRequest
+}
+
+// Check returns every rule value breaks, rather than stopping at the first one so users
+// can fix them all at once.
+func (p Policy) Check(value string) Violations {
+	var violations Violations
+	for _, rule := range p.Rules {
+		for _, description := range rule.Check(value) {
+			violations = append(violations, Violation{Field: p.Field, Description: description})
+		}
+	}
+	return violations
+}
+
+// LoginRules configure the policy of logins.
+type LoginRules struct {
+	MinLength int
+	MaxLength int
+	// Separators are the characters allowed besides letters and numbers. They can't start
+	// or end a login, nor follow each other more than MaxConsecutiveSeparators times.
+	Separators               string
+	MaxConsecutiveSeparators int
+	// ReservedWords can't be used as logins, ignoring separators and the differences of
+	// logins sharing a canonical form.
+	ReservedWords []string
+}
+
+var DefaultLoginRules = LoginRules{
+	MinLength:                6,
+	MaxLength:                25,
+	Separators:               "_.",
+	MaxConsecutiveSeparators: 1,
+	ReservedWords:            []string{"admin", "administrator", "moderator", "raidcomp", "root", "support", "system"},
+}
+
+func NewLoginPolicy(rules LoginRules) Policy {
+	separators := Characters(rules.Separators)
+	return Policy{
+		Field: "login",
+		Rules: []Rule{
+			Length{Min: rules.MinLength, Max: rules.MaxLength},
+			AllowedCharacters{Classes: []CharacterClass{Letter, Number, separators}},
+			NoAffix{Class: separators},
+			MaxConsecutive{Class: separators, Max: rules.MaxConsecutiveSeparators},
+			ReservedWords{Words: rules.ReservedWords, Ignore: rules.Separators},
+		},
+	}
+}
+
+// PasswordRules configure the policy of passwords.
+type PasswordRules struct {
+	MinLength int
+	MaxLength int
+	// RequiredClasses must each be used at least once.
+	RequiredClasses []CharacterClass
+	// ReservedWords can't be part of passwords, ignoring case and look-alikes. Common passwords are better
+	// caught by checking for breached passwords.
+	ReservedWords []string
+	// Breaches, when set, rejects passwords seen in breaches at least MinBreachCount times.
+	Breaches       *BreachCorpus
+	MinBreachCount int
+}
+
+var DefaultPasswordRules = PasswordRules{
+	MinLength:       8,
+	MaxLength:       128,
+	RequiredClasses: []CharacterClass{Lowercase, Uppercase, Number, Special},
+	ReservedWords:   []string{"raidcomp"},
+	MinBreachCount:  1,
+}
+
+func NewPasswordPolicy(rules PasswordRules) Policy {
+	passwordPolicy := Policy{
+		Field: "password",
+		Rules: []Rule{
+			Length{Min: rules.MinLength, Max: rules.MaxLength},
+			AllowedCharacters{Classes: []CharacterClass{Letter, Number, Special}},
+			RequiredCharacters{Classes: rules.RequiredClasses},
+			ReservedWords{Words: rules.ReservedWords, Contains: true},
+		},
+	}
+	if rules.Breaches != nil {
+		passwordPolicy.Rules = append(passwordPolicy.Rules, BreachedPassword{Corpus: rules.Breaches, MinCount: rules.MinBreachCount})
+	}
+	return passwordPolicy
+}
+
+// plural returns "<n> <noun>" with noun made plural unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
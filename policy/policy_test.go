@@ -0,0 +1,95 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+)
+
+func descriptions(violations Violations) []string {
+	var descriptions []string
+	for _, violation := range violations {
+		descriptions = append(descriptions, violation.Description)
+	}
+	return descriptions
+}
+
+func TestLoginPolicy(t *testing.T) {
+	tests := []struct {
+		login string
+		want  []string
+	}{
+		{login: "raider"},
+		{login: "raid.er_1"},
+		{login: "Räider"},
+		{login: "raid", want: []string{"must be at least 6 characters long"}},
+		{login: "raiderraiderraiderraiderraider", want: []string{"must be at most 25 characters long"}},
+		{login: "raid er", want: []string{`must not contain ' '`}},
+		{login: "_raider.", want: []string{`must not start with '_' or '.'`, `must not end with '_' or '.'`}},
+		{login: "raid._er", want: []string{`must not have more than 1 of '_' or '.' in a row`}},
+		{login: "Ad_min_istrator", want: []string{"must not be a reserved word"}},
+		{login: "ａｄｍｉｎｉｓｔｒａｔｏｒ", want: []string{"must not be a reserved word"}},
+		{login: "аdmіnistrator", want: []string{"must not be a reserved word"}},
+		{login: "ｒａｉｄ_ｃｏｍｐ", want: []string{"must not be a reserved word"}},
+		{login: "_ad", want: []string{"must be at least 6 characters long", `must not start with '_' or '.'`}},
+	}
+
+	loginPolicy := NewLoginPolicy(DefaultLoginRules)
+	for _, tt := range tests {
+		t.Run(tt.login, func(t *testing.T) {
+			violations := loginPolicy.Check(tt.login)
+
+			got := descriptions(violations)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("got violations %q, want %q", got, tt.want)
+			}
+			for _, violation := range violations {
+				if violation.Field != "login" {
+					t.Errorf("got violation of field %q, want login", violation.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	tests := []struct {
+		password string
+		want     []string
+	}{
+		{password: "Raid3r!Password"},
+		{password: "R4id!", want: []string{"must be at least 8 characters long"}},
+		{password: "raiderpassword", want: []string{"must contain an uppercase letter", "must contain a number", "must contain a special character"}},
+		{password: "Raid3r!\x00Password", want: []string{`must not contain '\x00'`}},
+		{password: "My!Raidcomp1", want: []string{`must not contain "raidcomp"`}},
+		{password: "My!Ｒａｉｄсomp1", want: []string{`must not contain "raidcomp"`}},
+	}
+
+	passwordPolicy := NewPasswordPolicy(DefaultPasswordRules)
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			got := descriptions(passwordPolicy.Check(tt.password))
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("got violations %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViolationsBadRequest(t *testing.T) {
+	violations := Violations{
+		{Field: "login", Description: "must be at least 6 characters long"},
+		{Field: "password", Description: "must contain a number"},
+	}
+
+	if got, want := violations.Error(), "login must be at least 6 characters long, password must contain a number"; got != want {
+		t.Errorf("got error %q, want %q", got, want)
+	}
+
+	fieldViolations := violations.BadRequest().FieldViolations
+	if len(fieldViolations) != 2 {
+		t.Fatalf("got %d field violations, want 2", len(fieldViolations))
+	}
+	if fieldViolations[1].Field != "password" || fieldViolations[1].Description != "password must contain a number" {
+		t.Errorf("got field violation %v", fieldViolations[1])
+	}
+}
@@ -0,0 +1,169 @@
+package policy
+
+import (
+	"fmt"
+	"github.com/raidcomp/users-service/daos"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CharacterClass is a named set of characters, e.g. "a number".
+type CharacterClass struct {
+	Name string
+	Is   func(r rune) bool
+}
+
+var (
+	Letter    = CharacterClass{Name: "a letter", Is: unicode.IsLetter}
+	Lowercase = CharacterClass{Name: "a lowercase letter", Is: unicode.IsLower}
+	Uppercase = CharacterClass{Name: "an uppercase letter", Is: unicode.IsUpper}
+	Number    = CharacterClass{Name: "a number", Is: unicode.IsNumber}
+	Special   = CharacterClass{Name: "a special character", Is: func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSymbol(r)
+	}}
+)
+
+// Characters returns the class of the characters of chars, named after them, e.g.
+// `"_" or "."`.
+func Characters(chars string) CharacterClass {
+	var quoted []string
+	for _, r := range chars {
+		quoted = append(quoted, strconv.QuoteRune(r))
+	}
+	name := strings.Join(quoted, " or ")
+
+	return CharacterClass{Name: name, Is: func(r rune) bool {
+		return strings.ContainsRune(chars, r)
+	}}
+}
+
+// Length requires between Min and Max characters. Zero bounds are not checked.
+type Length struct {
+	Min int
+	Max int
+}
+
+func (l Length) Check(value string) []string {
+	length := utf8.RuneCountInString(value)
+	if l.Min > 0 && length < l.Min {
+		return []string{fmt.Sprintf("must be at least %s long", plural(l.Min, "character"))}
+	}
+	if l.Max > 0 && length > l.Max {
+		return []string{fmt.Sprintf("must be at most %s long", plural(l.Max, "character"))}
+	}
+	return nil
+}
+
+// AllowedCharacters requires every character to be of one of Classes.
+type AllowedCharacters struct {
+	Classes []CharacterClass
+}
+
+func (a AllowedCharacters) Check(value string) []string {
+	for _, r := range value {
+		if !isAny(a.Classes, r) {
+			return []string{fmt.Sprintf("must not contain %q", r)}
+		}
+	}
+	return nil
+}
+
+// RequiredCharacters requires at least one character of each of Classes.
+type RequiredCharacters struct {
+	Classes []CharacterClass
+}
+
+func (rc RequiredCharacters) Check(value string) []string {
+	var descriptions []string
+	for _, class := range rc.Classes {
+		if strings.IndexFunc(value, class.Is) < 0 {
+			descriptions = append(descriptions, "must contain "+class.Name)
+		}
+	}
+	return descriptions
+}
+
+// NoAffix forbids starting or ending with a character of Class.
+type NoAffix struct {
+	Class CharacterClass
+}
+
+func (n NoAffix) Check(value string) []string {
+	var descriptions []string
+	if first, _ := utf8.DecodeRuneInString(value); value != "" && n.Class.Is(first) {
+		descriptions = append(descriptions, "must not start with "+n.Class.Name)
+	}
+	if last, _ := utf8.DecodeLastRuneInString(value); value != "" && n.Class.Is(last) {
+		descriptions = append(descriptions, "must not end with "+n.Class.Name)
+	}
+	return descriptions
+}
+
+// MaxConsecutive forbids more than Max characters of Class in a row.
+type MaxConsecutive struct {
+	Class CharacterClass
+	Max   int
+}
+
+func (m MaxConsecutive) Check(value string) []string {
+	consecutive := 0
+	for _, r := range value {
+		if !m.Class.Is(r) {
+			consecutive = 0
+			continue
+		}
+		consecutive++
+		if consecutive > m.Max {
+			return []string{fmt.Sprintf("must not have more than %d of %s in a row", m.Max, m.Class.Name)}
+		}
+	}
+	return nil
+}
+
+// ReservedWords forbids Words, ignoring the characters of Ignore and the differences
+// daos.CanonicalLogin ignores, such as case, fullwidth letters and look-alike letters of
+// other scripts. With Contains the words can't be part of values either.
+type ReservedWords struct {
+	Words    []string
+	Ignore   string
+	Contains bool
+}
+
+func (rw ReservedWords) Check(value string) []string {
+	normalized := rw.normalize(value)
+	for _, word := range rw.Words {
+		word = rw.normalize(word)
+		if word == "" {
+			continue
+		}
+		if normalized == word {
+			return []string{"must not be a reserved word"}
+		}
+		if rw.Contains && strings.Contains(normalized, word) {
+			return []string{fmt.Sprintf("must not contain %q", word)}
+		}
+	}
+	return nil
+}
+
+// normalize returns the canonical form of s without the characters of Ignore. Values are
+// canonicalized first, so compatibility forms of the ignored characters are ignored too.
+func (rw ReservedWords) normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(rw.Ignore, r) {
+			return -1
+		}
+		return r
+	}, daos.CanonicalLogin(s))
+}
+
+func isAny(classes []CharacterClass, r rune) bool {
+	for _, class := range classes {
+		if class.Is(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,268 @@
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"github.com/raidcomp/users-service/auth"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// Roles granting access beyond a user's own data.
+const (
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+	// RoleServicePrefix prefixes the roles of other services, e.g. service:raids.
+	RoleServicePrefix = "service:"
+)
+
+// anyService matches the role of every service in a MethodPolicy.
+const anyService = RoleServicePrefix + "*"
+
+// Identity is the authenticated caller of an RPC.
+type Identity struct {
+	// UserID is set for users calling with an access token.
+	UserID string
+	// Service is set for services calling with a client certificate.
+	Service string
+	Roles   []string
+}
+
+// HasRole reports whether the identity has role. The role "service:*" matches every
+// service.
+func (i Identity) HasRole(role string) bool {
+	for _, r := range i.Roles {
+		if r == role || (role == anyService && strings.HasPrefix(r, RoleServicePrefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+type identityKey struct{}
+
+// IdentityFromContext returns the caller authenticated by the Authorizer, if any.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+// MethodPolicy says who may call a method. Callers are allowed if any of its rules
+// allows them.
+type MethodPolicy struct {
+	// Public methods can be called without credentials, e.g. because they check
+	// credentials of their own.
+	Public bool
+	// Roles may call the method. "service:*" matches every service.
+	Roles []string
+	// Self lets users call the method on their own user ID.
+	Self bool
+	// Privileged reports whether a request asks for more than a Public method allows
+	// anyone, only Roles may then make it.
+	Privileged func(req interface{}) bool
+}
+
+var (
+	adminOnly          = []string{RoleAdmin}
+	adminsAndServices  = []string{RoleAdmin, anyService}
+	staffAndServices   = []string{RoleAdmin, RoleModerator, anyService}
+	selfAdminsServices = MethodPolicy{Roles: adminsAndServices, Self: true}
+)
+
+// withExternalIdentity reports whether a CreateUserRequest links an external identity,
+// which only callers vouching for it may do like with LinkExternalIdentity.
+func withExternalIdentity(req interface{}) bool {
+	createUser, ok := req.(*pb.CreateUserRequest)
+	return ok && createUser.ExternalIdentity != nil
+}
+
+// DefaultMethodPolicies are the policies of the Users service methods by method name.
+// Methods without a policy can't be called.
+var DefaultMethodPolicies = map[string]MethodPolicy{
+	"CreateUser":                 {Public: true, Roles: adminsAndServices, Privileged: withExternalIdentity},
+	"GetUser":                    {Roles: staffAndServices, Self: true},
+	"CheckUserPassword":          {Roles: adminsAndServices},
+	"UpdateUser":                 selfAdminsServices,
+	"DeleteUser":                 selfAdminsServices,
+	"Login":                      {Public: true},
+	"RefreshSession":             {Public: true},
+	"Logout":                     {Public: true},
+	"GetJWKS":                    {Public: true},
+	"VerifyToken":                {Public: true},
+	"SendVerificationEmail":      selfAdminsServices,
+	"VerifyEmail":                {Public: true},
+	"RequestPasswordReset":       {Public: true},
+	"ConfirmPasswordReset":       {Public: true},
+	"BeginTOTPEnrollment":        selfAdminsServices,
+	"ConfirmTOTPEnrollment":      selfAdminsServices,
+	"DisableTOTP":                selfAdminsServices,
+	"VerifyTOTP":                 {Public: true},
+	"GenerateRecoveryCodes":      selfAdminsServices,
+	"VerifyRecoveryCode":         {Public: true},
+	"BeginWebAuthnRegistration":  selfAdminsServices,
+	"FinishWebAuthnRegistration": selfAdminsServices,
+	"BeginWebAuthnAssertion":     {Public: true},
+	"FinishWebAuthnAssertion":    {Public: true},
+	// Only services completing the provider's flow can vouch for the identity
+	"LinkExternalIdentity":      {Roles: adminsAndServices},
+	"UnlinkExternalIdentity":    selfAdminsServices,
+	"GetUserByExternalIdentity": {Roles: adminsAndServices},
+	"ListUsers":                 {Roles: staffAndServices},
+	"BatchGetUsers":             {Roles: staffAndServices},
+	"SearchUsers":               {Roles: staffAndServices},
+	"SetUserRoles":              {Roles: adminOnly},
+}
+
+// Authorizer authenticates the callers of RPCs and authorizes them against the policy of
+// the method called.
+// Users are authenticated by an access token in the authorization metadata, as
+// "Bearer <token>", and get the roles it was issued with. Services are authenticated by
+// their verified TLS client certificate, and get the role service:<name> of the service
+// its SANs are listed for. Services may then only call the methods they are allowed.
+// Certificates of services not listed are rejected.
+type Authorizer struct {
+	Tokens *auth.TokenIssuer
+	// Policies are keyed by method name, e.g. GetUser.
+	Policies map[string]MethodPolicy
+
+	services *serviceRegistry
+}
+
+// NewAuthorizer returns an Authorizer with the default policies, trusting the client
+// certificates of services only.
+func NewAuthorizer(tokens *auth.TokenIssuer, services []Service) (*Authorizer, error) {
+	registry, err := newServiceRegistry(services, DefaultMethodPolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Authorizer{
+		Tokens:   tokens,
+		Policies: DefaultMethodPolicies,
+		services: registry,
+	}, nil
+}
+
+// UnaryInterceptor authorizes each call before handling it, and adds the caller's
+// Identity to the context of the handler.
+func (a *Authorizer) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	method := strings.TrimPrefix(info.FullMethod, "/"+pb.Users_ServiceDesc.ServiceName+"/")
+	policy, ok := a.Policies[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "%s can't be called", info.FullMethod)
+	}
+
+	identity, authenticated, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if authenticated {
+		ctx = context.WithValue(ctx, identityKey{}, identity)
+	}
+
+	privileged := policy.Privileged != nil && policy.Privileged(req)
+	if policy.Public && !privileged {
+		return handler(ctx, req)
+	}
+	if privileged && !policy.grantsRole(identity) {
+		return nil, status.Errorf(codes.PermissionDenied, "not allowed to make this %s request", method)
+	}
+	if !authenticated {
+		return nil, status.Errorf(codes.Unauthenticated, "credentials are required")
+	}
+	if !policy.allows(identity, req) {
+		return nil, status.Errorf(codes.PermissionDenied, "not allowed to call %s", method)
+	}
+	if identity.Service != "" && !a.services.allows(identity.Service, method) {
+		return nil, status.Errorf(codes.PermissionDenied, "service %s is not allowed to call %s", identity.Service, method)
+	}
+
+	return handler(ctx, req)
+}
+
+// allows reports whether identity may call the method with req.
+func (p MethodPolicy) allows(identity Identity, req interface{}) bool {
+	if p.grantsRole(identity) {
+		return true
+	}
+
+	if p.Self && identity.UserID != "" {
+		withID, ok := req.(interface{ GetId() string })
+		return ok && withID.GetId() == identity.UserID
+	}
+
+	return false
+}
+
+// grantsRole reports whether identity has one of the roles that may call the method.
+func (p MethodPolicy) grantsRole(identity Identity) bool {
+	for _, role := range p.Roles {
+		if identity.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticate returns the identity of the caller, and whether the caller presented
+// credentials. Invalid access tokens are rejected rather than ignored.
+func (a *Authorizer) authenticate(ctx context.Context) (Identity, bool, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) > 0 {
+		if !strings.HasPrefix(values[0], "Bearer ") {
+			return Identity{}, false, status.Errorf(codes.Unauthenticated, "authorization must be a bearer token")
+		}
+
+		claims, err := a.Tokens.ParseAccessToken(strings.TrimPrefix(values[0], "Bearer "))
+		if err != nil {
+			return Identity{}, false, status.Errorf(codes.Unauthenticated, "invalid access token")
+		}
+
+		return Identity{UserID: claims.Subject, Roles: userRoles(claims.Roles)}, true, nil
+	}
+
+	cert := peerCertificate(ctx)
+	if cert == nil {
+		return Identity{}, false, nil
+	}
+
+	service, ok := a.services.find(cert)
+	if !ok {
+		return Identity{}, false, status.Errorf(codes.Unauthenticated, "client certificate is not of a known service")
+	}
+
+	return Identity{Service: service.Name, Roles: []string{RoleServicePrefix + service.Name}}, true, nil
+}
+
+// userRoles returns the roles of an access token without service roles, which only client
+// certificates of listed services grant, so users can't call methods as a service.
+func userRoles(roles []string) []string {
+	var kept []string
+	for _, role := range roles {
+		if !strings.HasPrefix(role, RoleServicePrefix) {
+			kept = append(kept, role)
+		}
+	}
+	return kept
+}
+
+// peerCertificate returns the verified TLS client certificate of the caller, if any.
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0]
+}
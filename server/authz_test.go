@@ -0,0 +1,367 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func bearerContext(t *testing.T, userID string, roles ...string) context.Context {
+	t.Helper()
+
+	token, _, err := testTokens.IssueAccessToken(userID, "raider", roles)
+	if err != nil {
+		t.Fatalf("issuing token: %v", err)
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func certificateContext(cert *x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+	})
+}
+
+func newTestAuthorizer(t *testing.T, services []Service) *Authorizer {
+	t.Helper()
+
+	authorizer, err := NewAuthorizer(testTokens, services)
+	if err != nil {
+		t.Fatalf("creating authorizer: %v", err)
+	}
+	return authorizer
+}
+
+// testServices may call every method services can.
+var testServices = []Service{
+	{Name: "gateway", SANs: []string{"gateway.raidcomp.internal"}, Methods: []string{"CreateUser"}},
+	{Name: "raids", SANs: []string{"raids.raidcomp.internal"}, Methods: []string{"CheckUserPassword", "GetUser"}},
+}
+
+// authorize calls method through the interceptor of an authorizer trusting testServices,
+// returning the identity the handler got.
+func authorize(ctx context.Context, method string, req interface{}) (Identity, error) {
+	authorizer, _ := NewAuthorizer(testTokens, testServices)
+	return authorizeWith(ctx, authorizer, method, req)
+}
+
+func authorizeWith(ctx context.Context, authorizer *Authorizer, method string, req interface{}) (Identity, error) {
+	var identity Identity
+	info := &grpc.UnaryServerInfo{FullMethod: "/" + pb.Users_ServiceDesc.ServiceName + "/" + method}
+	_, err := authorizer.UnaryInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		identity, _ = IdentityFromContext(ctx)
+		return nil, nil
+	})
+	return identity, err
+}
+
+func TestAuthorizer(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		req    interface{}
+		want   codes.Code
+	}{
+		{
+			name:   "public without credentials",
+			ctx:    context.Background(),
+			method: "Login",
+			req:    &pb.LoginRequest{},
+			want:   codes.OK,
+		},
+		{
+			name:   "anonymous sign-up",
+			ctx:    context.Background(),
+			method: "CreateUser",
+			req:    &pb.CreateUserRequest{Login: "raider"},
+			want:   codes.OK,
+		},
+		{
+			name:   "anonymous sign-up with an external identity",
+			ctx:    context.Background(),
+			method: "CreateUser",
+			req:    &pb.CreateUserRequest{Login: "raider", ExternalIdentity: &pb.ExternalIdentity{Provider: pb.ExternalIdentityProvider_DISCORD, Subject: "80351110224678912"}},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "user signing up with an external identity",
+			ctx:    bearerContext(t, "user-id"),
+			method: "CreateUser",
+			req:    &pb.CreateUserRequest{Login: "raider", ExternalIdentity: &pb.ExternalIdentity{Provider: pb.ExternalIdentityProvider_DISCORD, Subject: "80351110224678912"}},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "service signing up with an external identity",
+			ctx:    certificateContext(&x509.Certificate{DNSNames: []string{"gateway.raidcomp.internal"}}),
+			method: "CreateUser",
+			req:    &pb.CreateUserRequest{Login: "raider", ExternalIdentity: &pb.ExternalIdentity{Provider: pb.ExternalIdentityProvider_DISCORD, Subject: "80351110224678912"}},
+			want:   codes.OK,
+		},
+		{
+			name:   "no credentials",
+			ctx:    context.Background(),
+			method: "GetUser",
+			req:    &pb.GetUserRequest{Id: "user-id"},
+			want:   codes.Unauthenticated,
+		},
+		{
+			name:   "invalid token",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer nope")),
+			method: "Login",
+			req:    &pb.LoginRequest{},
+			want:   codes.Unauthenticated,
+		},
+		{
+			name:   "not a bearer token",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic cmFpZGVy")),
+			method: "GetUser",
+			req:    &pb.GetUserRequest{Id: "user-id"},
+			want:   codes.Unauthenticated,
+		},
+		{
+			name:   "self",
+			ctx:    bearerContext(t, "user-id"),
+			method: "UpdateUser",
+			req:    &pb.UpdateUserRequest{Id: "user-id"},
+			want:   codes.OK,
+		},
+		{
+			name:   "other user",
+			ctx:    bearerContext(t, "user-id"),
+			method: "UpdateUser",
+			req:    &pb.UpdateUserRequest{Id: "other-id"},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "self by login",
+			ctx:    bearerContext(t, "user-id"),
+			method: "GetUser",
+			req:    &pb.GetUserRequest{Login: "raider"},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "moderator reading",
+			ctx:    bearerContext(t, "user-id", RoleModerator),
+			method: "GetUser",
+			req:    &pb.GetUserRequest{Id: "other-id"},
+			want:   codes.OK,
+		},
+		{
+			name:   "moderator writing",
+			ctx:    bearerContext(t, "user-id", RoleModerator),
+			method: "DeleteUser",
+			req:    &pb.DeleteUserRequest{Id: "other-id"},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "admin",
+			ctx:    bearerContext(t, "user-id", RoleAdmin),
+			method: "SetUserRoles",
+			req:    &pb.SetUserRolesRequest{Id: "other-id"},
+			want:   codes.OK,
+		},
+		{
+			name:   "user setting own roles",
+			ctx:    bearerContext(t, "user-id"),
+			method: "SetUserRoles",
+			req:    &pb.SetUserRolesRequest{Id: "user-id"},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "service",
+			ctx:    certificateContext(&x509.Certificate{DNSNames: []string{"raids.raidcomp.internal"}}),
+			method: "CheckUserPassword",
+			req:    &pb.CheckUserPasswordRequest{},
+			want:   codes.OK,
+		},
+		{
+			name:   "service setting roles",
+			ctx:    certificateContext(&x509.Certificate{DNSNames: []string{"raids.raidcomp.internal"}}),
+			method: "SetUserRoles",
+			req:    &pb.SetUserRolesRequest{Id: "user-id"},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "unknown method",
+			ctx:    bearerContext(t, "user-id", RoleAdmin),
+			method: "DropTable",
+			req:    &pb.GetUserRequest{},
+			want:   codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := authorize(tt.ctx, tt.method, tt.req)
+			assertCode(t, err, tt.want)
+		})
+	}
+}
+
+func TestAuthorizerIdentity(t *testing.T) {
+	identity, err := authorize(bearerContext(t, "user-id", RoleModerator), "GetUser", &pb.GetUserRequest{Id: "user-id"})
+	assertCode(t, err, codes.OK)
+	if identity.UserID != "user-id" || !identity.HasRole(RoleModerator) {
+		t.Errorf("got identity %+v, want user-id with the moderator role", identity)
+	}
+
+	identity, err = authorize(certificateContext(&x509.Certificate{DNSNames: []string{"raids.raidcomp.internal"}}), "GetUser", &pb.GetUserRequest{Id: "user-id"})
+	assertCode(t, err, codes.OK)
+	if identity.UserID != "" || identity.Service != "raids" || !identity.HasRole("service:raids") {
+		t.Errorf("got identity %+v, want the raids service", identity)
+	}
+}
+
+func TestAuthorizerServices(t *testing.T) {
+	spiffeID, err := url.Parse("spiffe://raidcomp.io/gateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorizer := newTestAuthorizer(t, []Service{
+		{Name: "gateway", SANs: []string{spiffeID.String()}, Methods: []string{"CheckUserPassword", "GetUser"}},
+		{Name: "raids", SANs: []string{"raids.raidcomp.internal"}, Methods: []string{"GetUser", "BatchGetUsers"}},
+	})
+	gateway := certificateContext(&x509.Certificate{URIs: []*url.URL{spiffeID}})
+	raids := certificateContext(&x509.Certificate{DNSNames: []string{"other.raidcomp.internal", "raids.raidcomp.internal"}})
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   codes.Code
+	}{
+		{name: "allowed by URI SAN", ctx: gateway, method: "CheckUserPassword", want: codes.OK},
+		{name: "allowed by DNS SAN", ctx: raids, method: "GetUser", want: codes.OK},
+		{name: "not allowed", ctx: raids, method: "CheckUserPassword", want: codes.PermissionDenied},
+		{name: "public", ctx: raids, method: "GetJWKS", want: codes.OK},
+		{name: "unknown service", ctx: certificateContext(&x509.Certificate{DNSNames: []string{"other.raidcomp.internal"}}), method: "GetJWKS", want: codes.Unauthenticated},
+		{name: "user", ctx: bearerContext(t, "user-id", RoleAdmin), method: "CheckUserPassword", want: codes.OK},
+		{name: "user with a service role", ctx: bearerContext(t, "user-id", "service:gateway"), method: "CheckUserPassword", want: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := authorizeWith(tt.ctx, authorizer, tt.method, &pb.GetUserRequest{})
+			assertCode(t, err, tt.want)
+		})
+	}
+
+	identity, err := authorizeWith(gateway, authorizer, "GetUser", &pb.GetUserRequest{})
+	assertCode(t, err, codes.OK)
+	if identity.Service != "gateway" || !identity.HasRole("service:gateway") {
+		t.Errorf("got identity %+v, want the gateway service", identity)
+	}
+}
+
+func TestAuthorizerWithoutServices(t *testing.T) {
+	authorizer := newTestAuthorizer(t, nil)
+	ctx := certificateContext(&x509.Certificate{Subject: pkix.Name{CommonName: "raids"}, DNSNames: []string{"raids.raidcomp.internal"}})
+
+	_, err := authorizeWith(ctx, authorizer, "GetUser", &pb.GetUserRequest{Id: "user-id"})
+	assertCode(t, err, codes.Unauthenticated)
+
+	_, err = authorizeWith(bearerContext(t, "user-id"), authorizer, "GetUser", &pb.GetUserRequest{Id: "user-id"})
+	assertCode(t, err, codes.OK)
+}
+
+func TestNewAuthorizerChecksServices(t *testing.T) {
+	tests := []struct {
+		name     string
+		services []Service
+	}{
+		{name: "invalid name", services: []Service{{Name: "Raids!", SANs: []string{"raids"}}}},
+		{name: "duplicate name", services: []Service{{Name: "raids", SANs: []string{"a"}}, {Name: "raids", SANs: []string{"b"}}}},
+		{name: "no SANs", services: []Service{{Name: "raids"}}},
+		{name: "shared SAN", services: []Service{{Name: "raids", SANs: []string{"a"}}, {Name: "gateway", SANs: []string{"a"}}}},
+		{name: "unknown method", services: []Service{{Name: "raids", SANs: []string{"raids"}, Methods: []string{"DropTable"}}}},
+		{name: "admin method", services: []Service{{Name: "raids", SANs: []string{"raids"}, Methods: []string{"SetUserRoles"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAuthorizer(testTokens, tt.services)
+			if err == nil {
+				t.Errorf("got no error")
+			}
+		})
+	}
+}
+
+func TestLoadServices(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "services.json")
+	err := os.WriteFile(path, []byte(`{"services": [{"name": "raids", "sans": ["raids.raidcomp.internal"], "methods": ["GetUser"]}]}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	services, err := LoadServices(path)
+	if err != nil {
+		t.Fatalf("loading services: %v", err)
+	}
+	if len(services) != 1 || services[0].Name != "raids" || services[0].SANs[0] != "raids.raidcomp.internal" || services[0].Methods[0] != "GetUser" {
+		t.Errorf("got services %+v", services)
+	}
+}
+
+func TestEveryMethodHasPolicy(t *testing.T) {
+	for _, method := range pb.Users_ServiceDesc.Methods {
+		if _, ok := DefaultMethodPolicies[method.MethodName]; !ok {
+			t.Errorf("%s has no policy", method.MethodName)
+		}
+	}
+}
+
+func TestSetUserRoles(t *testing.T) {
+	ctx := context.Background()
+	usersServer, _, users := newTestServer(t, "raider")
+	userID := users["raider"].UserID
+
+	for _, role := range []string{"root", "service:raids"} {
+		_, err := usersServer.SetUserRoles(ctx, &pb.SetUserRolesRequest{Id: userID, Roles: []string{role}})
+		assertCode(t, err, codes.InvalidArgument)
+	}
+
+	_, err := usersServer.SetUserRoles(ctx, &pb.SetUserRolesRequest{Id: "unknown", Roles: []string{RoleAdmin}})
+	assertCode(t, err, codes.NotFound)
+
+	setRoles, err := usersServer.SetUserRoles(ctx, &pb.SetUserRolesRequest{Id: userID, Roles: []string{RoleModerator, RoleAdmin}})
+	assertCode(t, err, codes.OK)
+	if len(setRoles.User.Roles) != 2 {
+		t.Errorf("got roles %v, want moderator and admin", setRoles.User.Roles)
+	}
+
+	// New access tokens carry the roles
+	login, err := usersServer.Login(ctx, &pb.LoginRequest{Login: "raider", Password: testPassword})
+	assertCode(t, err, codes.OK)
+	claims, err := testTokens.ParseAccessToken(login.Session.AccessToken)
+	if err != nil {
+		t.Fatalf("parsing access token: %v", err)
+	}
+	if len(claims.Roles) != 2 || claims.Roles[0] != RoleModerator {
+		t.Errorf("got token roles %v, want moderator and admin", claims.Roles)
+	}
+
+	cleared, err := usersServer.SetUserRoles(ctx, &pb.SetUserRolesRequest{Id: userID})
+	assertCode(t, err, codes.OK)
+	if len(cleared.User.Roles) != 0 {
+		t.Errorf("got roles %v, want none", cleared.User.Roles)
+	}
+
+	_, err = usersServer.DeleteUser(ctx, &pb.DeleteUserRequest{Id: userID})
+	assertCode(t, err, codes.OK)
+	_, err = usersServer.SetUserRoles(ctx, &pb.SetUserRolesRequest{Id: userID, Roles: []string{RoleAdmin}})
+	assertCode(t, err, codes.NotFound)
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/raidcomp/users-service/auth"
+	"github.com/raidcomp/users-service/clients"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+func (u usersServerImpl) SendVerificationEmail(ctx context.Context, req *pb.SendVerificationEmailRequest) (*pb.SendVerificationEmailResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	user, err := u.UsersDAO.GetUserByID(ctx, req.Id)
+	if err != nil {
+		return nil, daoError(err, "error getting user")
+	}
+
+	if user.EmailVerified {
+		return nil, status.Errorf(codes.FailedPrecondition, "email of userID %s is already verified", req.Id)
+	}
+
+	token, expiresAt, err := u.createToken(ctx, daos.Token{
+		Purpose: daos.TokenPurposeVerifyEmail,
+		UserID:  user.UserID,
+		SentTo:  user.Email,
+	}, u.TokenTTLs.EmailVerification)
+	if err != nil {
+		return nil, daoError(err, "error creating verification token")
+	}
+
+	err = u.Mailer.SendVerificationEmail(ctx, clients.VerificationEmail{
+		To:        user.Email,
+		Login:     user.Login,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "error sending verification email")
+	}
+
+	return &pb.SendVerificationEmailResponse{}, nil
+}
+
+func (u usersServerImpl) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	token, err := u.TokensDAO.ConsumeToken(ctx, daos.TokenPurposeVerifyEmail, auth.HashToken(req.Token))
+	if errors.Is(err, daos.ErrNotFound) {
+		return nil, status.Errorf(codes.InvalidArgument, "verification token is invalid or expired")
+	} else if err != nil {
+		return nil, daoError(err, "error consuming verification token")
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		return nil, status.Errorf(codes.InvalidArgument, "verification token is invalid or expired")
+	}
+
+	// The token is void if the user was deleted or its email changed since it was sent
+	user, err := u.UsersDAO.MarkEmailVerified(ctx, token.UserID, token.SentTo)
+	if errors.Is(err, daos.ErrNotFound) || errors.Is(err, daos.ErrEmailChanged) {
+		return nil, status.Errorf(codes.InvalidArgument, "verification token is invalid or expired")
+	} else if err != nil {
+		return nil, daoError(err, "error verifying email")
+	}
+
+	return &pb.VerifyEmailResponse{
+		User: toPBUser(*user),
+	}, nil
+}
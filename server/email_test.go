@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"testing"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	deps, users := newTestDependencies(t, "raider")
+	usersServer := NewUsersServer(deps)
+	mailer := deps.Mailer.(*recordingMailer)
+	raider := users["raider"]
+
+	_, err := usersServer.SendVerificationEmail(ctx, &pb.SendVerificationEmailRequest{Id: raider.UserID})
+	assertCode(t, err, codes.OK)
+
+	if len(mailer.verificationEmails) != 1 {
+		t.Fatalf("got %d verification emails, want 1", len(mailer.verificationEmails))
+	}
+	email := mailer.verificationEmails[0]
+	if email.To != raider.Email || email.Token == "" {
+		t.Fatalf("got email to %s with token %q", email.To, email.Token)
+	}
+
+	resp, err := usersServer.VerifyEmail(ctx, &pb.VerifyEmailRequest{Token: email.Token})
+	assertCode(t, err, codes.OK)
+	if !resp.User.EmailVerified {
+		t.Errorf("email is not verified")
+	}
+
+	// Tokens are single-use, and verified users don't get another one
+	_, err = usersServer.VerifyEmail(ctx, &pb.VerifyEmailRequest{Token: email.Token})
+	assertCode(t, err, codes.InvalidArgument)
+
+	_, err = usersServer.SendVerificationEmail(ctx, &pb.SendVerificationEmailRequest{Id: raider.UserID})
+	assertCode(t, err, codes.FailedPrecondition)
+
+	newEmail := "raider@example.com"
+	updated, err := deps.UsersDAO.UpdateUser(ctx, raider.UserID, daos.UserUpdate{Email: &newEmail})
+	if err != nil {
+		t.Fatalf("updating email: %v", err)
+	}
+	if updated.EmailVerified {
+		t.Errorf("email is still verified after changing it")
+	}
+}
+
+func TestVerifyEmailAfterEmailChange(t *testing.T) {
+	ctx := context.Background()
+	deps, users := newTestDependencies(t, "raider")
+	usersServer := NewUsersServer(deps)
+	mailer := deps.Mailer.(*recordingMailer)
+	raider := users["raider"]
+
+	_, err := usersServer.SendVerificationEmail(ctx, &pb.SendVerificationEmailRequest{Id: raider.UserID})
+	assertCode(t, err, codes.OK)
+
+	newEmail := "raider@example.com"
+	_, err = deps.UsersDAO.UpdateUser(ctx, raider.UserID, daos.UserUpdate{Email: &newEmail})
+	if err != nil {
+		t.Fatalf("updating email: %v", err)
+	}
+
+	_, err = usersServer.VerifyEmail(ctx, &pb.VerifyEmailRequest{Token: mailer.verificationEmails[0].Token})
+	assertCode(t, err, codes.InvalidArgument)
+
+	stored, _ := deps.UsersDAO.GetUserByID(ctx, raider.UserID)
+	if stored.EmailVerified {
+		t.Errorf("new email was verified with a token sent to the old email")
+	}
+}
+
+func TestSendVerificationEmailUnknownUser(t *testing.T) {
+	usersServer, _, _ := newTestServer(t)
+
+	_, err := usersServer.SendVerificationEmail(context.Background(), &pb.SendVerificationEmailRequest{Id: "unknown"})
+	assertCode(t, err, codes.NotFound)
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"github.com/raidcomp/users-service/daos"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"log"
+	"time"
+)
+
+// errorDomain is the domain of the ErrorInfo details of storage errors.
+const errorDomain = "users.raidcomp.io"
+
+// throttledRetryDelay is how long clients are told to wait when storage throttled them.
+const throttledRetryDelay = time.Second
+
+// Reasons of the ErrorInfo details of storage errors.
+const (
+	reasonNotFound      = "NOT_FOUND"
+	reasonConflict      = "CONFLICT"
+	reasonThrottled     = "THROTTLED"
+	reasonCorruptRecord = "CORRUPT_RECORD"
+	// Invalid arguments and failed preconditions are the client's to fix, not retry.
+	reasonInvalidArgument    = "INVALID_ARGUMENT"
+	reasonFailedPrecondition = "FAILED_PRECONDITION"
+)
+
+// daoError converts an error of a DAO into a status error, with an ErrorInfo detail
+// telling its kind apart. message describes the failed operation, e.g. "error getting
+// user". Errors that aren't the client's to handle are logged, as their cause isn't
+// returned.
+func daoError(err error, message string) error {
+	switch {
+	case errors.Is(err, daos.ErrNotFound):
+		return errorWithInfo(codes.NotFound, err.Error(), reasonNotFound, 0)
+	case errors.Is(err, daos.ErrConflict):
+		return errorWithInfo(codes.Aborted, fmt.Sprintf("%s: conflicting write, try again", message), reasonConflict, 0)
+	case errors.Is(err, daos.ErrThrottled):
+		return errorWithInfo(codes.Unavailable, fmt.Sprintf("%s: too many requests, try again later", message), reasonThrottled, throttledRetryDelay)
+	case errors.Is(err, daos.ErrInvalidArgument):
+		return errorWithInfo(codes.InvalidArgument, err.Error(), reasonInvalidArgument, 0)
+	case errors.Is(err, daos.ErrFailedPrecondition):
+		return errorWithInfo(codes.FailedPrecondition, err.Error(), reasonFailedPrecondition, 0)
+	case errors.Is(err, daos.ErrCorruptRecord):
+		log.Printf("%s: %v", message, err)
+		return errorWithInfo(codes.DataLoss, fmt.Sprintf("%s: corrupt record", message), reasonCorruptRecord, 0)
+	}
+
+	log.Printf("%s: %v", message, err)
+	return status.Errorf(codes.Internal, "%s", message)
+}
+
+// errorWithInfo returns an error with an ErrorInfo detail of reason, and a RetryInfo
+// detail when retryDelay is set.
+func errorWithInfo(code codes.Code, message, reason string, retryDelay time.Duration) error {
+	st := status.New(code, message)
+	info := &errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}
+
+	var (
+		withDetails *status.Status
+		err         error
+	)
+	if retryDelay > 0 {
+		withDetails, err = st.WithDetails(info, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(retryDelay),
+		})
+	} else {
+		withDetails, err = st.WithDetails(info)
+	}
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
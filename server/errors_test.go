@@ -0,0 +1,57 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"github.com/raidcomp/users-service/daos"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestDAOError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		want       codes.Code
+		wantReason string
+		wantRetry  bool
+	}{
+		{name: "not found", err: daos.ErrRecoveryCodeNotFound, want: codes.NotFound, wantReason: reasonNotFound},
+		{name: "conflict", err: daos.ErrConcurrentModification, want: codes.Aborted, wantReason: reasonConflict},
+		{name: "throttled", err: daos.ErrUnprocessedKeys, want: codes.Unavailable, wantReason: reasonThrottled, wantRetry: true},
+		{name: "invalid argument", err: daos.ErrInvalidPageToken, want: codes.InvalidArgument, wantReason: reasonInvalidArgument},
+		{name: "failed precondition", err: daos.ErrTooManyWebAuthnCredentials, want: codes.FailedPrecondition, wantReason: reasonFailedPrecondition},
+		{name: "corrupt record", err: &daos.Error{Kind: daos.ErrCorruptRecord, Err: errors.New("unmarshal failed")}, want: codes.DataLoss, wantReason: reasonCorruptRecord},
+		{name: "wrapped", err: fmt.Errorf("getting user: %w", daos.ErrNotFound), want: codes.NotFound, wantReason: reasonNotFound},
+		{name: "other", err: errors.New("connection reset"), want: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := daoError(tt.err, "error getting user")
+			assertCode(t, err, tt.want)
+
+			var reason string
+			var retry bool
+			for _, detail := range status.Convert(err).Details() {
+				switch detail := detail.(type) {
+				case *errdetails.ErrorInfo:
+					if detail.Domain != errorDomain {
+						t.Errorf("got domain %s, want %s", detail.Domain, errorDomain)
+					}
+					reason = detail.Reason
+				case *errdetails.RetryInfo:
+					retry = true
+				}
+			}
+			if reason != tt.wantReason {
+				t.Errorf("got reason %q, want %q", reason, tt.wantReason)
+			}
+			if retry != tt.wantRetry {
+				t.Errorf("got retry info %v, want %v", retry, tt.wantRetry)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+var externalIdentityProviders = map[pb.ExternalIdentityProvider]daos.ExternalIdentityProvider{
+	pb.ExternalIdentityProvider_DISCORD:    daos.ExternalIdentityProviderDiscord,
+	pb.ExternalIdentityProvider_BATTLE_NET: daos.ExternalIdentityProviderBattleNet,
+	pb.ExternalIdentityProvider_GOOGLE:     daos.ExternalIdentityProviderGoogle,
+}
+
+func toDAOExternalIdentity(identity *pb.ExternalIdentity) daos.ExternalIdentity {
+	return daos.ExternalIdentity{
+		Provider: externalIdentityProviders[identity.Provider],
+		Subject:  identity.Subject,
+		LinkedAt: time.Now(),
+	}
+}
+
+func toPBExternalIdentities(identities []daos.ExternalIdentity) []*pb.ExternalIdentity {
+	var pbIdentities []*pb.ExternalIdentity
+	for _, identity := range identities {
+		for pbProvider, provider := range externalIdentityProviders {
+			if provider == identity.Provider {
+				pbIdentities = append(pbIdentities, &pb.ExternalIdentity{
+					Provider: pbProvider,
+					Subject:  identity.Subject,
+					LinkedAt: timestamppb.New(identity.LinkedAt),
+				})
+			}
+		}
+	}
+	return pbIdentities
+}
+
+func (u usersServerImpl) LinkExternalIdentity(ctx context.Context, req *pb.LinkExternalIdentityRequest) (*pb.LinkExternalIdentityResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	user, err := u.UsersDAO.LinkExternalIdentity(ctx, req.Id, toDAOExternalIdentity(req.Identity))
+	if errors.Is(err, daos.ErrExternalIdentityTaken) {
+		return nil, status.Errorf(codes.AlreadyExists, "%s identity is already linked to another user", req.Identity.Provider)
+	} else if errors.Is(err, daos.ErrConcurrentModification) {
+		return nil, status.Errorf(codes.Aborted, "user was modified concurrently, try again")
+	} else if err != nil {
+		return nil, daoError(err, "error linking external identity")
+	}
+
+	return &pb.LinkExternalIdentityResponse{
+		User: toPBUser(*user),
+	}, nil
+}
+
+func (u usersServerImpl) UnlinkExternalIdentity(ctx context.Context, req *pb.UnlinkExternalIdentityRequest) (*pb.UnlinkExternalIdentityResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	user, err := u.UsersDAO.GetUserByID(ctx, req.Id)
+	if err != nil {
+		return nil, daoError(err, "error getting user")
+	}
+
+	// Users without a password would be locked out
+	if user.HashedPassword == "" && len(user.WebAuthnCredentialIDs) == 0 && len(user.ExternalIdentities) <= 1 {
+		return nil, status.Errorf(codes.FailedPrecondition, "userID %s can't unlink its only way to sign in, set a password first", req.Id)
+	}
+
+	updatedUser, err := u.UsersDAO.UnlinkExternalIdentity(ctx, req.Id, externalIdentityProviders[req.Provider])
+	if errors.Is(err, daos.ErrConcurrentModification) {
+		return nil, status.Errorf(codes.Aborted, "user was modified concurrently, try again")
+	} else if err != nil {
+		return nil, daoError(err, "error unlinking external identity")
+	}
+
+	return &pb.UnlinkExternalIdentityResponse{
+		User: toPBUser(*updatedUser),
+	}, nil
+}
+
+func (u usersServerImpl) GetUserByExternalIdentity(ctx context.Context, req *pb.GetUserByExternalIdentityRequest) (*pb.GetUserByExternalIdentityResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	user, err := u.UsersDAO.GetUserByExternalIdentity(ctx, externalIdentityProviders[req.Provider], req.Subject)
+	if errors.Is(err, daos.ErrNotFound) {
+		return &pb.GetUserByExternalIdentityResponse{}, nil
+	} else if err != nil {
+		return nil, daoError(err, "error getting user")
+	}
+
+	return &pb.GetUserByExternalIdentityResponse{
+		User: toPBUser(*user),
+	}, nil
+}
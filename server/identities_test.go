@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"testing"
+	"time"
+)
+
+func discordIdentity(subject string) *pb.ExternalIdentity {
+	return &pb.ExternalIdentity{Provider: pb.ExternalIdentityProvider_DISCORD, Subject: subject}
+}
+
+func TestLinkExternalIdentity(t *testing.T) {
+	ctx := context.Background()
+	usersServer, _, users := newTestServer(t, "raider", "healer")
+	raider := users["raider"]
+
+	linked, err := usersServer.LinkExternalIdentity(ctx, &pb.LinkExternalIdentityRequest{Id: raider.UserID, Identity: discordIdentity("1234")})
+	assertCode(t, err, codes.OK)
+	if identities := linked.User.ExternalIdentities; len(identities) != 1 || identities[0].Subject != "1234" {
+		t.Fatalf("got identities %v", identities)
+	}
+
+	found, err := usersServer.GetUserByExternalIdentity(ctx, &pb.GetUserByExternalIdentityRequest{Provider: pb.ExternalIdentityProvider_DISCORD, Subject: "1234"})
+	assertCode(t, err, codes.OK)
+	if found.User.GetId() != raider.UserID {
+		t.Errorf("got user %v, want %s", found.User, raider.UserID)
+	}
+
+	t.Run("linked again", func(t *testing.T) {
+		_, err := usersServer.LinkExternalIdentity(ctx, &pb.LinkExternalIdentityRequest{Id: raider.UserID, Identity: discordIdentity("1234")})
+		assertCode(t, err, codes.OK)
+	})
+
+	t.Run("linked to another user", func(t *testing.T) {
+		_, err := usersServer.LinkExternalIdentity(ctx, &pb.LinkExternalIdentityRequest{Id: users["healer"].UserID, Identity: discordIdentity("1234")})
+		assertCode(t, err, codes.AlreadyExists)
+	})
+
+	t.Run("second identity of a provider", func(t *testing.T) {
+		_, err := usersServer.LinkExternalIdentity(ctx, &pb.LinkExternalIdentityRequest{Id: raider.UserID, Identity: discordIdentity("5678")})
+		assertCode(t, err, codes.FailedPrecondition)
+	})
+
+	t.Run("unspecified provider", func(t *testing.T) {
+		identity := &pb.ExternalIdentity{Subject: "1234"}
+		_, err := usersServer.LinkExternalIdentity(ctx, &pb.LinkExternalIdentityRequest{Id: raider.UserID, Identity: identity})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := usersServer.LinkExternalIdentity(ctx, &pb.LinkExternalIdentityRequest{Id: "unknown", Identity: discordIdentity("9999")})
+		assertCode(t, err, codes.NotFound)
+	})
+}
+
+func TestUnlinkExternalIdentity(t *testing.T) {
+	ctx := context.Background()
+	usersServer, usersDAO, users := newTestServer(t, "raider")
+	raider := users["raider"]
+
+	_, err := usersServer.UnlinkExternalIdentity(ctx, &pb.UnlinkExternalIdentityRequest{Id: raider.UserID, Provider: pb.ExternalIdentityProvider_GOOGLE})
+	assertCode(t, err, codes.NotFound)
+
+	_, err = usersServer.LinkExternalIdentity(ctx, &pb.LinkExternalIdentityRequest{Id: raider.UserID, Identity: discordIdentity("1234")})
+	assertCode(t, err, codes.OK)
+
+	unlinked, err := usersServer.UnlinkExternalIdentity(ctx, &pb.UnlinkExternalIdentityRequest{Id: raider.UserID, Provider: pb.ExternalIdentityProvider_DISCORD})
+	assertCode(t, err, codes.OK)
+	if len(unlinked.User.ExternalIdentities) != 0 {
+		t.Errorf("got identities %v after unlinking", unlinked.User.ExternalIdentities)
+	}
+
+	found, err := usersServer.GetUserByExternalIdentity(ctx, &pb.GetUserByExternalIdentityRequest{Provider: pb.ExternalIdentityProvider_DISCORD, Subject: "1234"})
+	assertCode(t, err, codes.OK)
+	if found.User != nil {
+		t.Errorf("got user %v for an unlinked identity", found.User)
+	}
+
+	t.Run("only way to sign in", func(t *testing.T) {
+		passwordless, err := usersDAO.CreateUser(ctx, "passwordless", "passwordless@raidcomp.io", "", daos.ExternalIdentity{
+			Provider: daos.ExternalIdentityProviderBattleNet,
+			Subject:  "4321",
+			LinkedAt: time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("creating user: %v", err)
+		}
+
+		_, err = usersServer.UnlinkExternalIdentity(ctx, &pb.UnlinkExternalIdentityRequest{Id: passwordless.UserID, Provider: pb.ExternalIdentityProvider_BATTLE_NET})
+		assertCode(t, err, codes.FailedPrecondition)
+	})
+}
+
+func TestPasswordlessUser(t *testing.T) {
+	ctx := context.Background()
+	usersServer, usersDAO, _ := newTestServer(t)
+
+	passwordless, err := usersDAO.CreateUser(ctx, "passwordless", "passwordless@raidcomp.io", "", daos.ExternalIdentity{
+		Provider: daos.ExternalIdentityProviderGoogle,
+		Subject:  "1234",
+		LinkedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("creating user: %v", err)
+	}
+
+	_, err = usersServer.CheckUserPassword(ctx, &pb.CheckUserPasswordRequest{Id: passwordless.UserID, Password: "Wr0ng!Password"})
+	assertCode(t, err, codes.Unauthenticated)
+
+	got, err := usersServer.GetUser(ctx, &pb.GetUserRequest{Id: passwordless.UserID})
+	assertCode(t, err, codes.OK)
+	if got.User.HasPassword {
+		t.Errorf("got hasPassword for a user without a password")
+	}
+
+	_, err = usersServer.CreateUser(ctx, &pb.CreateUserRequest{Login: "raider", Email: "raider@raidcomp.io"})
+	assertCode(t, err, codes.InvalidArgument)
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/raidcomp/users-service/auth"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log"
+	"net/http"
+)
+
+const JWKSPath = "/.well-known/jwks.json"
+
+// NewJWKSHandler serves the public signing keys as a JSON Web Key Set.
+func NewJWKSHandler(keys *auth.KeySet) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(JWKSPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		err := json.NewEncoder(w).Encode(struct {
+			Keys []auth.JSONWebKey `json:"keys"`
+		}{
+			Keys: keys.JWKS(),
+		})
+		if err != nil {
+			log.Printf("error writing JWKS: %v", err)
+		}
+	})
+
+	return mux
+}
+
+func (u usersServerImpl) GetJWKS(ctx context.Context, req *pb.GetJWKSRequest) (*pb.GetJWKSResponse, error) {
+	jwks := u.Tokens.Keys.JWKS()
+
+	keys := make([]*pb.JSONWebKey, 0, len(jwks))
+	for _, jwk := range jwks {
+		keys = append(keys, &pb.JSONWebKey{
+			Kty: jwk.KeyType,
+			Kid: jwk.KeyID,
+			Use: jwk.Use,
+			Alg: jwk.Algorithm,
+			Crv: jwk.Curve,
+			X:   jwk.X,
+			N:   jwk.N,
+			E:   jwk.E,
+		})
+	}
+
+	return &pb.GetJWKSResponse{
+		Keys: keys,
+	}, nil
+}
+
+func (u usersServerImpl) VerifyToken(ctx context.Context, req *pb.VerifyTokenRequest) (*pb.VerifyTokenResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	claims, err := u.Tokens.ParseAccessToken(req.AccessToken)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "access token is invalid or expired")
+	}
+
+	tokenClaims := &pb.TokenClaims{
+		UserId:  claims.Subject,
+		Login:   claims.Login,
+		TokenId: claims.ID,
+		Issuer:  claims.Issuer,
+		Roles:   claims.Roles,
+	}
+	if claims.IssuedAt != nil {
+		tokenClaims.IssuedAt = timestamppb.New(claims.IssuedAt.Time)
+	}
+	if claims.ExpiresAt != nil {
+		tokenClaims.ExpiresAt = timestamppb.New(claims.ExpiresAt.Time)
+	}
+
+	return &pb.VerifyTokenResponse{
+		Claims: tokenClaims,
+	}, nil
+}
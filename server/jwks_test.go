@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetJWKS(t *testing.T) {
+	usersServer, _, _ := newTestServer(t)
+
+	resp, err := usersServer.GetJWKS(context.Background(), &pb.GetJWKSRequest{})
+	assertCode(t, err, codes.OK)
+
+	if len(resp.Keys) != 1 {
+		t.Fatalf("got %d keys, want 1", len(resp.Keys))
+	}
+
+	key := resp.Keys[0]
+	if key.Kid != testTokens.Keys.ActiveKey().ID || key.Kty != "OKP" || key.Alg != "EdDSA" || key.X == "" {
+		t.Errorf("unexpected key %v", key)
+	}
+}
+
+func TestJWKSHandler(t *testing.T) {
+	handler := NewJWKSHandler(testTokens.Keys)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, JWKSPath, nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	err := json.Unmarshal(recorder.Body.Bytes(), &jwks)
+	if err != nil {
+		t.Fatalf("decoding JWKS: %v", err)
+	}
+	if len(jwks.Keys) != 1 || jwks.Keys[0]["kid"] != testTokens.Keys.ActiveKey().ID {
+		t.Errorf("unexpected JWKS %s", recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, JWKSPath, nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("got status %d for POST, want %d", recorder.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestVerifyToken(t *testing.T) {
+	ctx := context.Background()
+	usersServer, _, users := newTestServer(t, "raider")
+
+	login, err := usersServer.Login(ctx, &pb.LoginRequest{Login: "raider", Password: testPassword})
+	assertCode(t, err, codes.OK)
+
+	otherKeyToken, _, err := newTestTokenIssuer().IssueAccessToken(users["raider"].UserID, "raider", nil)
+	if err != nil {
+		t.Fatalf("issuing token: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  codes.Code
+	}{
+		{name: "valid token", token: login.Session.AccessToken, want: codes.OK},
+		{name: "signed with unknown key", token: otherKeyToken, want: codes.Unauthenticated},
+		{name: "malformed token", token: "not-a-token", want: codes.Unauthenticated},
+		{name: "refresh token", token: login.Session.RefreshToken, want: codes.Unauthenticated},
+		{name: "empty token", want: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := usersServer.VerifyToken(ctx, &pb.VerifyTokenRequest{AccessToken: tt.token})
+			assertCode(t, err, tt.want)
+			if tt.want != codes.OK {
+				return
+			}
+
+			if resp.Claims.UserId != users["raider"].UserID || resp.Claims.Login != "raider" {
+				t.Errorf("unexpected claims %v", resp.Claims)
+			}
+			if !resp.Claims.ExpiresAt.AsTime().Equal(login.Session.AccessTokenExpiresAt.AsTime().Truncate(time.Second)) {
+				t.Errorf("got expiry %v, want %v", resp.Claims.ExpiresAt.AsTime(), login.Session.AccessTokenExpiresAt.AsTime())
+			}
+		})
+	}
+}
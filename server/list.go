@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultPageSize = 50
+
+func (u usersServerImpl) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	filter := daos.ListUsersFilter{LoginPrefix: req.LoginPrefix}
+	if req.CreatedAfter != nil {
+		createdAfter := req.CreatedAfter.AsTime()
+		filter.CreatedAfter = &createdAfter
+	}
+	if req.CreatedBefore != nil {
+		createdBefore := req.CreatedBefore.AsTime()
+		filter.CreatedBefore = &createdBefore
+	}
+
+	users, nextPageToken, err := u.UsersDAO.ListUsers(ctx, filter, pageSize, req.PageToken)
+	if err != nil {
+		return nil, daoError(err, "error listing users")
+	}
+
+	return &pb.ListUsersResponse{
+		Users:         toPBUsers(users),
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func toPBUsers(users []daos.User) []*pb.User {
+	pbUsers := make([]*pb.User, 0, len(users))
+	for _, user := range users {
+		pbUsers = append(pbUsers, toPBUser(user))
+	}
+	return pbUsers
+}
+
+// maxBatchGetUsers is the most IDs and logins BatchGetUsers accepts at once.
+const maxBatchGetUsers = 100
+
+func (u usersServerImpl) BatchGetUsers(ctx context.Context, req *pb.BatchGetUsersRequest) (*pb.BatchGetUsersResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	if len(req.Ids)+len(req.Logins) > maxBatchGetUsers {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ids and logins can be requested at once", maxBatchGetUsers)
+	}
+
+	usersByID, err := u.UsersDAO.BatchGetUsersByID(ctx, req.Ids)
+	if err != nil {
+		return nil, daoError(err, "error getting users")
+	}
+
+	usersByLogin, err := u.UsersDAO.BatchGetUsersByLogin(ctx, req.Logins)
+	if err != nil {
+		return nil, daoError(err, "error getting users")
+	}
+
+	resp := &pb.BatchGetUsersResponse{}
+	for _, id := range req.Ids {
+		if user, ok := usersByID[id]; ok {
+			resp.Users = append(resp.Users, toPBUser(user))
+		} else {
+			resp.MissingIds = append(resp.MissingIds, id)
+		}
+	}
+	for _, login := range req.Logins {
+		if user, ok := usersByLogin[login]; ok {
+			resp.Users = append(resp.Users, toPBUser(user))
+		} else {
+			resp.MissingLogins = append(resp.MissingLogins, login)
+		}
+	}
+
+	return resp, nil
+}
+
+const defaultSearchLimit = 10
+
+func (u usersServerImpl) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest) (*pb.SearchUsersResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	users, nextPageToken, err := u.UsersDAO.SearchByLoginPrefix(ctx, req.LoginPrefix, limit, req.PageToken)
+	if err != nil {
+		return nil, daoError(err, "error searching users")
+	}
+
+	return &pb.SearchUsersResponse{
+		Users:         toPBUsers(users),
+		NextPageToken: nextPageToken,
+	}, nil
+}
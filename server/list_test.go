@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sort"
+	"testing"
+	"time"
+)
+
+func logins(users []*pb.User) []string {
+	var logins []string
+	for _, user := range users {
+		logins = append(logins, user.Login)
+	}
+	sort.Strings(logins)
+	return logins
+}
+
+func TestListUsers(t *testing.T) {
+	ctx := context.Background()
+	usersServer, usersDAO, users := newTestServer(t, "raider", "raidlead", "healer", "tank")
+	_, err := usersDAO.DeleteUser(ctx, users["tank"].UserID, false)
+	if err != nil {
+		t.Fatalf("deleting user: %v", err)
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		var listed []*pb.User
+		pageToken := ""
+		for pages := 0; ; pages++ {
+			if pages > 3 {
+				t.Fatalf("listed more pages than users")
+			}
+
+			resp, err := usersServer.ListUsers(ctx, &pb.ListUsersRequest{PageSize: 1, PageToken: pageToken})
+			assertCode(t, err, codes.OK)
+			if len(resp.Users) > 1 {
+				t.Fatalf("got %d users in a page of 1", len(resp.Users))
+			}
+
+			listed = append(listed, resp.Users...)
+			pageToken = resp.NextPageToken
+			if pageToken == "" {
+				break
+			}
+		}
+
+		if got := logins(listed); len(got) != 3 || got[0] != "healer" || got[1] != "raider" || got[2] != "raidlead" {
+			t.Errorf("listed %v, want the users that are not deleted", got)
+		}
+	})
+
+	t.Run("login prefix", func(t *testing.T) {
+		resp, err := usersServer.ListUsers(ctx, &pb.ListUsersRequest{LoginPrefix: "raid"})
+		assertCode(t, err, codes.OK)
+		if got := logins(resp.Users); len(got) != 2 || resp.NextPageToken != "" {
+			t.Errorf("listed %v (next page %q), want raider and raidlead", got, resp.NextPageToken)
+		}
+	})
+
+	t.Run("created range", func(t *testing.T) {
+		resp, err := usersServer.ListUsers(ctx, &pb.ListUsersRequest{CreatedAfter: timestamppb.New(time.Now())})
+		assertCode(t, err, codes.OK)
+		if len(resp.Users) != 0 {
+			t.Errorf("listed %v created in the future", logins(resp.Users))
+		}
+
+		resp, err = usersServer.ListUsers(ctx, &pb.ListUsersRequest{CreatedBefore: timestamppb.New(time.Now())})
+		assertCode(t, err, codes.OK)
+		if len(resp.Users) != 3 {
+			t.Errorf("listed %v, want all users", logins(resp.Users))
+		}
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		_, err := usersServer.ListUsers(ctx, &pb.ListUsersRequest{PageToken: "not a token"})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("page too large", func(t *testing.T) {
+		_, err := usersServer.ListUsers(ctx, &pb.ListUsersRequest{PageSize: 1000})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+}
+
+func TestBatchGetUsers(t *testing.T) {
+	ctx := context.Background()
+	usersServer, _, users := newTestServer(t, "raider", "healer", "tank")
+
+	resp, err := usersServer.BatchGetUsers(ctx, &pb.BatchGetUsersRequest{
+		Ids:    []string{users["tank"].UserID, "unknown", users["raider"].UserID},
+		Logins: []string{"healer", "nobody"},
+	})
+	assertCode(t, err, codes.OK)
+
+	var got []string
+	for _, user := range resp.Users {
+		got = append(got, user.Login)
+	}
+	if len(got) != 3 || got[0] != "tank" || got[1] != "raider" || got[2] != "healer" {
+		t.Errorf("got users %v, want tank, raider and healer in request order", got)
+	}
+	if len(resp.MissingIds) != 1 || resp.MissingIds[0] != "unknown" {
+		t.Errorf("got missing ids %v", resp.MissingIds)
+	}
+	if len(resp.MissingLogins) != 1 || resp.MissingLogins[0] != "nobody" {
+		t.Errorf("got missing logins %v", resp.MissingLogins)
+	}
+
+	t.Run("too many keys", func(t *testing.T) {
+		ids := make([]string, 60)
+		logins := make([]string, 60)
+		for i := range ids {
+			ids[i] = users["raider"].UserID
+			logins[i] = "raider"
+		}
+
+		_, err := usersServer.BatchGetUsers(ctx, &pb.BatchGetUsersRequest{Ids: ids, Logins: logins})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+}
+
+func TestSearchUsers(t *testing.T) {
+	ctx := context.Background()
+	usersServer, usersDAO, users := newTestServer(t, "raider", "Raidlead", "raidtank", "healer")
+	_, err := usersDAO.DeleteUser(ctx, users["raidtank"].UserID, false)
+	if err != nil {
+		t.Fatalf("deleting user: %v", err)
+	}
+
+	resp, err := usersServer.SearchUsers(ctx, &pb.SearchUsersRequest{LoginPrefix: "RAID", Limit: 1})
+	assertCode(t, err, codes.OK)
+	if len(resp.Users) != 1 || resp.Users[0].Login != "raider" || resp.NextPageToken == "" {
+		t.Fatalf("got users %v (next page %q), want raider and a next page", logins(resp.Users), resp.NextPageToken)
+	}
+
+	resp, err = usersServer.SearchUsers(ctx, &pb.SearchUsersRequest{LoginPrefix: "RAID", Limit: 1, PageToken: resp.NextPageToken})
+	assertCode(t, err, codes.OK)
+	if len(resp.Users) != 1 || resp.Users[0].Login != "Raidlead" || resp.NextPageToken != "" {
+		t.Errorf("got users %v (next page %q), want Raidlead on the last page", logins(resp.Users), resp.NextPageToken)
+	}
+
+	t.Run("prefix too short", func(t *testing.T) {
+		_, err := usersServer.SearchUsers(ctx, &pb.SearchUsersRequest{LoginPrefix: "r"})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/raidcomp/users-service/auth"
+	"github.com/raidcomp/users-service/clients"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"time"
+)
+
+func (u usersServerImpl) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	var users []daos.User
+	if req.Login != "" {
+		user, err := u.UsersDAO.GetUserByLogin(ctx, req.Login)
+		if err == nil {
+			users = append(users, *user)
+		} else if !errors.Is(err, daos.ErrNotFound) {
+			return nil, daoError(err, "error getting user")
+		}
+	} else if req.Email != "" {
+		users, err = u.UsersDAO.GetUsersByEmail(ctx, req.Email)
+		if err != nil {
+			return nil, daoError(err, "error getting user")
+		}
+	} else {
+		return nil, status.Errorf(codes.InvalidArgument, "login or email must be set")
+	}
+
+	// Failures are only logged so the response doesn't tell whether a user was found
+	for _, user := range users {
+		token, expiresAt, err := u.createToken(ctx, daos.Token{
+			Purpose: daos.TokenPurposeResetPassword,
+			UserID:  user.UserID,
+			SentTo:  user.Email,
+		}, u.TokenTTLs.PasswordReset)
+		if err != nil {
+			log.Printf("error creating password reset token for userID %s: %v", user.UserID, err)
+			continue
+		}
+
+		err = u.Mailer.SendPasswordResetEmail(ctx, clients.PasswordResetEmail{
+			To:        user.Email,
+			Login:     user.Login,
+			Token:     token,
+			ExpiresAt: expiresAt,
+		})
+		if err != nil {
+			log.Printf("error sending password reset email to userID %s: %v", user.UserID, err)
+		}
+	}
+
+	return &pb.RequestPasswordResetResponse{}, nil
+}
+
+func (u usersServerImpl) ConfirmPasswordReset(ctx context.Context, req *pb.ConfirmPasswordResetRequest) (*pb.ConfirmPasswordResetResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	token, err := u.TokensDAO.ConsumeToken(ctx, daos.TokenPurposeResetPassword, auth.HashToken(req.Token))
+	if errors.Is(err, daos.ErrNotFound) {
+		return nil, status.Errorf(codes.InvalidArgument, "password reset token is invalid or expired")
+	} else if err != nil {
+		return nil, daoError(err, "error consuming password reset token")
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		return nil, status.Errorf(codes.InvalidArgument, "password reset token is invalid or expired")
+	}
+
+	user, err := u.UsersDAO.GetUserByID(ctx, token.UserID)
+	if errors.Is(err, daos.ErrNotFound) {
+		return nil, status.Errorf(codes.InvalidArgument, "password reset token is invalid or expired")
+	} else if err != nil {
+		return nil, daoError(err, "error getting user")
+	}
+
+	// Only whoever controls the user's current email may reset the password
+	if user.Email != token.SentTo {
+		return nil, status.Errorf(codes.InvalidArgument, "password reset token is invalid or expired")
+	}
+
+	// The password is only checked and hashed for valid tokens, so invalid tokens can't
+	// make the server hash passwords. Rejected passwords put the token back to retry.
+	var hashedPassword string
+	err = policyError(u.PasswordPolicy.Check(req.Password))
+	if err == nil {
+		hashedPassword, err = u.hashPassword(req.Password)
+	}
+	if err != nil {
+		restoreErr := u.TokensDAO.CreateToken(ctx, *token)
+		if restoreErr != nil {
+			log.Printf("error restoring password reset token of userID %s: %v", user.UserID, restoreErr)
+		}
+		return nil, err
+	}
+
+	updatedUser, err := u.UsersDAO.UpdateUser(ctx, user.UserID, daos.UserUpdate{
+		HashedPassword: &hashedPassword,
+		RevokeSessions: true,
+	})
+	if errors.Is(err, daos.ErrNotFound) {
+		return nil, status.Errorf(codes.InvalidArgument, "password reset token is invalid or expired")
+	} else if err != nil {
+		return nil, daoError(err, "error updating password")
+	}
+
+	// Proving control of the email also lifts any lock from failed password checks
+	u.recordSuccessfulLogin(ctx, *updatedUser)
+
+	return &pb.ConfirmPasswordResetResponse{
+		User: toPBUser(*updatedUser),
+	}, nil
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"github.com/raidcomp/users-service/auth"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+)
+
+func TestRequestPasswordReset(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *pb.RequestPasswordResetRequest
+		want       codes.Code
+		wantEmails int
+	}{
+		{name: "by login", req: &pb.RequestPasswordResetRequest{Login: "raider"}, want: codes.OK, wantEmails: 1},
+		{name: "by email", req: &pb.RequestPasswordResetRequest{Email: "raider@raidcomp.io"}, want: codes.OK, wantEmails: 1},
+		{name: "unknown login", req: &pb.RequestPasswordResetRequest{Login: "unknown"}, want: codes.OK},
+		{name: "unknown email", req: &pb.RequestPasswordResetRequest{Email: "unknown@raidcomp.io"}, want: codes.OK},
+		{name: "no login or email", req: &pb.RequestPasswordResetRequest{}, want: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, users := newTestDependencies(t, "raider")
+			usersServer := NewUsersServer(deps)
+			mailer := deps.Mailer.(*recordingMailer)
+
+			_, err := usersServer.RequestPasswordReset(context.Background(), tt.req)
+			assertCode(t, err, tt.want)
+
+			if len(mailer.passwordResetEmails) != tt.wantEmails {
+				t.Fatalf("got %d password reset emails, want %d", len(mailer.passwordResetEmails), tt.wantEmails)
+			}
+			if tt.wantEmails > 0 && mailer.passwordResetEmails[0].To != users["raider"].Email {
+				t.Errorf("got email to %s, want %s", mailer.passwordResetEmails[0].To, users["raider"].Email)
+			}
+		})
+	}
+}
+
+func TestConfirmPasswordReset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("new password", func(t *testing.T) {
+		deps, users := newTestDependencies(t, "raider")
+		usersServer := NewUsersServer(deps)
+		mailer := deps.Mailer.(*recordingMailer)
+
+		_, err := usersServer.RequestPasswordReset(ctx, &pb.RequestPasswordResetRequest{Login: "raider"})
+		assertCode(t, err, codes.OK)
+		token := mailer.passwordResetEmails[0].Token
+
+		resp, err := usersServer.ConfirmPasswordReset(ctx, &pb.ConfirmPasswordResetRequest{Token: token, Password: "N3w!Password"})
+		assertCode(t, err, codes.OK)
+		if resp.User.Id != users["raider"].UserID {
+			t.Errorf("got user %s, want %s", resp.User.Id, users["raider"].UserID)
+		}
+
+		_, err = usersServer.CheckUserPassword(ctx, &pb.CheckUserPasswordRequest{Login: "raider", Password: "N3w!Password"})
+		assertCode(t, err, codes.OK)
+		_, err = usersServer.CheckUserPassword(ctx, &pb.CheckUserPasswordRequest{Login: "raider", Password: testPassword})
+		assertCode(t, err, codes.Unauthenticated)
+
+		_, err = usersServer.ConfirmPasswordReset(ctx, &pb.ConfirmPasswordResetRequest{Token: token, Password: "An0ther!Password"})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		usersServer, _, _ := newTestServer(t)
+
+		_, err := usersServer.ConfirmPasswordReset(ctx, &pb.ConfirmPasswordResetRequest{Token: "unknown", Password: "N3w!Password"})
+		assertCode(t, err, codes.InvalidArgument)
+
+		// The token is checked before the password, which is never hashed for invalid tokens
+		_, err = usersServer.ConfirmPasswordReset(ctx, &pb.ConfirmPasswordResetRequest{Token: "unknown", Password: "password"})
+		if !strings.Contains(status.Convert(err).Message(), "token") {
+			t.Errorf("got %v, want the token to be refused before the password", err)
+		}
+	})
+
+	t.Run("invalid password keeps token", func(t *testing.T) {
+		deps, _ := newTestDependencies(t, "raider")
+		usersServer := NewUsersServer(deps)
+		mailer := deps.Mailer.(*recordingMailer)
+
+		_, err := usersServer.RequestPasswordReset(ctx, &pb.RequestPasswordResetRequest{Login: "raider"})
+		assertCode(t, err, codes.OK)
+		token := mailer.passwordResetEmails[0].Token
+
+		_, err = usersServer.ConfirmPasswordReset(ctx, &pb.ConfirmPasswordResetRequest{Token: token, Password: "password"})
+		assertCode(t, err, codes.InvalidArgument)
+
+		stored, err := deps.TokensDAO.ConsumeToken(ctx, daos.TokenPurposeResetPassword, auth.HashToken(token))
+		if err != nil || stored == nil {
+			t.Errorf("token was consumed by a rejected password (err: %v)", err)
+		}
+	})
+
+	t.Run("verification token", func(t *testing.T) {
+		deps, users := newTestDependencies(t, "raider")
+		usersServer := NewUsersServer(deps)
+		mailer := deps.Mailer.(*recordingMailer)
+
+		_, err := usersServer.SendVerificationEmail(ctx, &pb.SendVerificationEmailRequest{Id: users["raider"].UserID})
+		assertCode(t, err, codes.OK)
+
+		_, err = usersServer.ConfirmPasswordReset(ctx, &pb.ConfirmPasswordResetRequest{Token: mailer.verificationEmails[0].Token, Password: "N3w!Password"})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"github.com/raidcomp/users-service/policy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// policyError returns an InvalidArgument error describing every violation, with them
+// attached as BadRequest details, or nil without violations.
+func policyError(violations policy.Violations) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	st := status.New(codes.InvalidArgument, violations.Error())
+	withDetails, err := st.WithDetails(violations.BadRequest())
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"github.com/raidcomp/users-service/policy"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const breachedPassword = "Summer2023!"
+
+// newBreachPolicy returns a password policy rejecting breachedPassword.
+func newBreachPolicy(t *testing.T) *policy.Policy {
+	t.Helper()
+
+	sum := sha1.Sum([]byte(breachedPassword))
+	path := filepath.Join(t.TempDir(), "pwned-passwords.txt")
+	err := os.WriteFile(path, []byte(strings.ToUpper(hex.EncodeToString(sum[:]))+":42\n"), 0o600)
+	if err != nil {
+		t.Fatalf("writing breach corpus: %v", err)
+	}
+
+	corpus, err := policy.OpenBreachCorpus(path)
+	if err != nil {
+		t.Fatalf("opening breach corpus: %v", err)
+	}
+	t.Cleanup(func() { corpus.Close() })
+
+	rules := policy.DefaultPasswordRules
+	rules.Breaches = corpus
+	passwordPolicy := policy.NewPasswordPolicy(rules)
+	return &passwordPolicy
+}
+
+func TestBreachedPasswords(t *testing.T) {
+	ctx := context.Background()
+	deps, users := newTestDependencies(t, "raider")
+	deps.PasswordPolicy = newBreachPolicy(t)
+	usersServer := NewUsersServer(deps)
+
+	t.Run("create user", func(t *testing.T) {
+		_, err := usersServer.CreateUser(ctx, &pb.CreateUserRequest{Login: "healer", Email: "healer@raidcomp.io", Password: breachedPassword})
+		assertCode(t, err, codes.InvalidArgument)
+		if !strings.Contains(err.Error(), "data breach") {
+			t.Errorf("got error %v, want the breach as reason", err)
+		}
+
+		_, err = usersServer.CreateUser(ctx, &pb.CreateUserRequest{Login: "healer", Email: "healer@raidcomp.io", Password: testPassword})
+		assertCode(t, err, codes.OK)
+	})
+
+	t.Run("update user", func(t *testing.T) {
+		_, err := usersServer.UpdateUser(ctx, &pb.UpdateUserRequest{
+			Id:         users["raider"].UserID,
+			Password:   breachedPassword,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"password"}},
+		})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("confirm password reset", func(t *testing.T) {
+		_, err := usersServer.RequestPasswordReset(ctx, &pb.RequestPasswordResetRequest{Login: "raider"})
+		assertCode(t, err, codes.OK)
+		token := deps.Mailer.(*recordingMailer).passwordResetEmails[0].Token
+
+		_, err = usersServer.ConfirmPasswordReset(ctx, &pb.ConfirmPasswordResetRequest{Token: token, Password: breachedPassword})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+}
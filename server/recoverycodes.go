@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/raidcomp/users-service/auth"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const recoveryCodeCount = 10
+
+func (u usersServerImpl) GenerateRecoveryCodes(ctx context.Context, req *pb.GenerateRecoveryCodesRequest) (*pb.GenerateRecoveryCodesResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	user, err := u.UsersDAO.GetUserByID(ctx, req.Id)
+	if err != nil {
+		return nil, daoError(err, "error getting user")
+	}
+
+	if !user.TOTPEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "TOTP is not enabled for userID %s", req.Id)
+	}
+
+	err = u.checkOwnSecondFactor(ctx, *user, req.Code, req.RecoveryCode)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, codeHashes, err := auth.NewRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error creating recovery codes")
+	}
+
+	updatedUser, err := u.UsersDAO.SetRecoveryCodes(ctx, user.UserID, codeHashes)
+	if errors.Is(err, daos.ErrTOTPNotEnabled) {
+		return nil, status.Errorf(codes.FailedPrecondition, "TOTP is not enabled for userID %s", req.Id)
+	} else if err != nil {
+		return nil, daoError(err, "error storing recovery codes")
+	}
+
+	return &pb.GenerateRecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+		User:          toPBUser(*updatedUser),
+	}, nil
+}
+
+func (u usersServerImpl) VerifyRecoveryCode(ctx context.Context, req *pb.VerifyRecoveryCodeRequest) (*pb.VerifyRecoveryCodeResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	token, user, err := u.redeemSecondFactorToken(ctx, req.SecondFactorToken)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedUser, err := u.UsersDAO.ConsumeRecoveryCode(ctx, user.UserID, auth.HashRecoveryCode(req.RecoveryCode))
+	if errors.Is(err, daos.ErrNotFound) {
+		u.recordFailedLogin(ctx, user, "")
+		return nil, status.Errorf(codes.Unauthenticated, "recovery code is invalid")
+	} else if err != nil {
+		return nil, daoError(err, "error consuming recovery code")
+	}
+
+	u.recordSuccessfulLogin(ctx, *updatedUser)
+
+	session, err := u.completeSecondFactor(ctx, *token, *updatedUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.VerifyRecoveryCodeResponse{
+		User:    toPBUser(*updatedUser),
+		Session: session,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"github.com/raidcomp/users-service/auth"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	ctx := context.Background()
+	usersServer, _, users := newTestServer(t, "raider")
+	raider := users["raider"]
+
+	_, err := usersServer.GenerateRecoveryCodes(ctx, &pb.GenerateRecoveryCodesRequest{Id: raider.UserID})
+	assertCode(t, err, codes.FailedPrecondition)
+
+	enrollTOTP(t, usersServer, raider.UserID)
+
+	generated, err := usersServer.GenerateRecoveryCodes(ctx, &pb.GenerateRecoveryCodesRequest{Id: raider.UserID})
+	assertCode(t, err, codes.OK)
+	if len(generated.RecoveryCodes) != recoveryCodeCount || generated.User.RecoveryCodesRemaining != recoveryCodeCount {
+		t.Errorf("got %d codes with %d remaining, want %d", len(generated.RecoveryCodes), generated.User.RecoveryCodesRemaining, recoveryCodeCount)
+	}
+
+	disabled, err := usersServer.DisableTOTP(ctx, &pb.DisableTOTPRequest{Id: raider.UserID})
+	assertCode(t, err, codes.OK)
+	if disabled.User.RecoveryCodesRemaining != 0 {
+		t.Errorf("got %d recovery codes remaining after disabling TOTP", disabled.User.RecoveryCodesRemaining)
+	}
+}
+
+func TestVerifyRecoveryCode(t *testing.T) {
+	ctx := context.Background()
+	usersServer, _, users := newTestServer(t, "raider")
+	raider := users["raider"]
+	enrollTOTP(t, usersServer, raider.UserID)
+
+	generated, err := usersServer.GenerateRecoveryCodes(ctx, &pb.GenerateRecoveryCodesRequest{Id: raider.UserID})
+	assertCode(t, err, codes.OK)
+	recoveryCode := generated.RecoveryCodes[0]
+
+	login, err := usersServer.Login(ctx, &pb.LoginRequest{Login: "raider", Password: testPassword})
+	assertCode(t, err, codes.OK)
+
+	verified, err := usersServer.VerifyRecoveryCode(ctx, &pb.VerifyRecoveryCodeRequest{
+		SecondFactorToken: login.SecondFactorToken,
+		RecoveryCode:      strings.ToUpper(recoveryCode),
+	})
+	assertCode(t, err, codes.OK)
+	if verified.Session == nil {
+		t.Errorf("got no session completing a login")
+	}
+	if verified.User.RecoveryCodesRemaining != recoveryCodeCount-1 {
+		t.Errorf("got %d recovery codes remaining, want %d", verified.User.RecoveryCodesRemaining, recoveryCodeCount-1)
+	}
+
+	// Recovery codes are single-use
+	check, err := usersServer.CheckUserPassword(ctx, &pb.CheckUserPasswordRequest{Id: raider.UserID, Password: testPassword})
+	assertCode(t, err, codes.OK)
+
+	_, err = usersServer.VerifyRecoveryCode(ctx, &pb.VerifyRecoveryCodeRequest{
+		SecondFactorToken: check.SecondFactorToken,
+		RecoveryCode:      recoveryCode,
+	})
+	assertCode(t, err, codes.Unauthenticated)
+}
+
+func TestGenerateOwnRecoveryCodes(t *testing.T) {
+	usersServer, _, users := newTestServer(t, "raider")
+	raider := users["raider"]
+	secret := enrollTOTP(t, usersServer, raider.UserID)
+	ctx := selfContext(raider.UserID)
+
+	generated, err := usersServer.GenerateRecoveryCodes(context.Background(), &pb.GenerateRecoveryCodesRequest{Id: raider.UserID})
+	assertCode(t, err, codes.OK)
+
+	_, err = usersServer.GenerateRecoveryCodes(ctx, &pb.GenerateRecoveryCodesRequest{Id: raider.UserID})
+	assertCode(t, err, codes.PermissionDenied)
+
+	_, err = usersServer.GenerateRecoveryCodes(ctx, &pb.GenerateRecoveryCodesRequest{Id: raider.UserID, Code: auth.TOTPCode(secret, auth.TOTPStep(time.Now())+10), RecoveryCode: generated.RecoveryCodes[0]})
+	assertCode(t, err, codes.PermissionDenied)
+
+	regenerated, err := usersServer.GenerateRecoveryCodes(ctx, &pb.GenerateRecoveryCodesRequest{Id: raider.UserID, RecoveryCode: generated.RecoveryCodes[0]})
+	assertCode(t, err, codes.OK)
+	if len(regenerated.RecoveryCodes) != recoveryCodeCount || regenerated.RecoveryCodes[0] == generated.RecoveryCodes[0] {
+		t.Errorf("got recovery codes %v, want new ones", regenerated.RecoveryCodes)
+	}
+}
@@ -0,0 +1,24 @@
+package server
+
+import (
+	"context"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (u usersServerImpl) SetUserRoles(ctx context.Context, req *pb.SetUserRolesRequest) (*pb.SetUserRolesResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	user, err := u.UsersDAO.SetUserRoles(ctx, req.Id, req.Roles)
+	if err != nil {
+		return nil, daoError(err, "error setting user roles")
+	}
+
+	return &pb.SetUserRolesResponse{
+		User: toPBUser(*user),
+	}, nil
+}
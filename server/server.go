@@ -4,135 +4,191 @@ import (
 	"context"
 	"errors"
 	"github.com/raidcomp/users-service/auth"
+	"github.com/raidcomp/users-service/clients"
 	"github.com/raidcomp/users-service/daos"
+	"github.com/raidcomp/users-service/policy"
 	pb "github.com/raidcomp/users-service/proto"
+	"github.com/raidcomp/users-service/webauthn"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
-	"unicode"
+	"log"
+	"time"
 )
 
+// Dependencies are what the users server is built from.
+type Dependencies struct {
+	UsersDAO    daos.UsersDAO
+	SessionsDAO daos.SessionsDAO
+	TokensDAO   daos.TokensDAO
+	Tokens      *auth.TokenIssuer
+	Passwords   *auth.PasswordHasher
+	Mailer      clients.Mailer
+	// Secrets encrypts second factor secrets at rest.
+	Secrets *auth.SecretBox
+	// WebAuthn is the relying party passkeys are registered for.
+	WebAuthn *webauthn.RelyingParty
+	// Throttle defaults to the default lockout policies when nil.
+	Throttle *LoginThrottle
+	// LoginPolicy and PasswordPolicy default to the default login and password rules
+	// when nil.
+	LoginPolicy    *policy.Policy
+	PasswordPolicy *policy.Policy
+	// TokenTTLs defaults to DefaultTokenTTLs when nil.
+	TokenTTLs *TokenTTLs
+}
+
 type usersServerImpl struct {
 	pb.UnimplementedUsersServer
-
-	UsersDAO daos.UsersDAO
+	Dependencies
 }
 
-func NewUsersServer(usersDAO daos.UsersDAO) pb.UsersServer {
+func NewUsersServer(deps Dependencies) pb.UsersServer {
+	if deps.Throttle == nil {
+		deps.Throttle = NewLoginThrottle(DefaultAccountLockout, DefaultAddressThrottle, false)
+	}
+	if deps.LoginPolicy == nil {
+		loginPolicy := policy.NewLoginPolicy(policy.DefaultLoginRules)
+		deps.LoginPolicy = &loginPolicy
+	}
+	if deps.PasswordPolicy == nil {
+		passwordPolicy := policy.NewPasswordPolicy(policy.DefaultPasswordRules)
+		deps.PasswordPolicy = &passwordPolicy
+	}
+	if deps.TokenTTLs == nil {
+		tokenTTLs := DefaultTokenTTLs
+		deps.TokenTTLs = &tokenTTLs
+	}
+
 	return usersServerImpl{
-		UsersDAO: usersDAO,
+		Dependencies: deps,
 	}
 }
 
-func validateLogin(login string) error {
-	if login == "" {
-		return errors.New("login must not be empty")
+// findUser gets a user by ID first, then login.
+func (u usersServerImpl) findUser(ctx context.Context, id, login string) (*daos.User, error) {
+	if id != "" {
+		return u.UsersDAO.GetUserByID(ctx, id)
+	} else if login != "" {
+		return u.UsersDAO.GetUserByLogin(ctx, login)
 	}
+	return nil, daos.ErrNotFound
+}
 
-	var (
-		hasNotAllowed                  = false
-		hasUnderscoreAtStart           = false
-		hasUnderscoreAtEnd             = false
-		hasPeriodAtStart               = false
-		hasPeriodAtEnd                 = false
-		hasUnderscoreAndPeriodTogether = false
-	)
+// hashPassword hashes password with the current hasher, returning a status error on failure.
+func (u usersServerImpl) hashPassword(password string) (string, error) {
+	hashedPassword, err := u.Passwords.Hash(password)
+	if errors.Is(err, auth.ErrPasswordTooLong) {
+		return "", status.Errorf(codes.InvalidArgument, "password is too long")
+	} else if err != nil {
+		return "", status.Errorf(codes.Internal, "error hashing password")
+	}
 
-	for i, char := range login {
-		if !unicode.IsLetter(char) || !unicode.IsNumber(char) || !(char == '_') || !(char == '.') {
-			hasNotAllowed = true
-			break
-		}
+	return hashedPassword, nil
+}
 
-		if i == 0 {
-			if char == '_' {
-				hasUnderscoreAtStart = true
-				break
-			} else if char == '.' {
-				hasPeriodAtStart = true
-				break
-			}
-		}
+// checkPassword reports whether password is the password of user, which may be nil when
+// the user is unknown. Hashes made with an outdated algorithm or parameters are replaced
+// by a new hash once the password is known.
+func (u usersServerImpl) checkPassword(ctx context.Context, user *daos.User, password string) bool {
+	// Unknown users and users created through an external identity, who may have no
+	// password, take as long to fail as a wrong password
+	if user == nil || user.HashedPassword == "" {
+		u.Passwords.VerifyDummy(password)
+		return false
+	}
 
-		if i == len(login)-1 {
-			if char == '_' {
-				hasUnderscoreAtEnd = true
-				break
-			} else if char == '.' {
-				hasPeriodAtEnd = true
-				break
-			}
-		}
+	ok, needsRehash, err := u.Passwords.Verify(user.HashedPassword, password)
+	if err != nil {
+		log.Printf("error verifying password hash of userID %s: %v", user.UserID, err)
+		return false
+	}
 
-		if i < len(login)-1 {
-			nextChar := login[i+1]
-			if (char == '_' || char == '.') && (nextChar == '_' || nextChar == '.') {
-				hasUnderscoreAndPeriodTogether = true
-				break
-			}
+	if needsRehash {
+		hashedPassword, err := u.Passwords.Hash(password)
+		if err == nil {
+			_, err = u.UsersDAO.UpdateUser(ctx, user.UserID, daos.UserUpdate{HashedPassword: &hashedPassword})
+		}
+		if err != nil {
+			// The password is still correct, the hash will be upgraded on a later check
+			log.Printf("error rehashing password of userID %s: %v", user.UserID, err)
 		}
 	}
 
-	if !hasNotAllowed {
-		return errors.New("login must only contain letters or numbers")
-	} else if !hasUnderscoreAtStart {
-		return errors.New("login must not start with an underscore")
-	} else if !hasUnderscoreAtEnd {
-		return errors.New("login must not end with an underscore")
-	} else if !hasPeriodAtStart {
-		return errors.New("login must not start with a period")
-	} else if !hasPeriodAtEnd {
-		return errors.New("login must not end with a period")
-	} else if !hasUnderscoreAndPeriodTogether {
-		return errors.New("login must not have two consecutive underscores or periods")
-	} else {
+	return ok
+}
+
+// checkOwnPassword verifies the current password of a request when users call for
+// themselves, so a stolen access token alone can't take over their account by changing
+// its password or email. Admins and services calling for other users don't need one.
+func (u usersServerImpl) checkOwnPassword(ctx context.Context, id, currentPassword string) error {
+	identity, ok := IdentityFromContext(ctx)
+	if !ok || identity.UserID != id {
 		return nil
 	}
-}
 
-func validatePassword(password string) error {
-	if password == "" {
-		return errors.New("password must not be empty")
+	user, err := u.UsersDAO.GetUserByID(ctx, id)
+	if err != nil {
+		return daoError(err, "error getting user")
 	}
 
-	var (
-		hasUpper      = false
-		hasLower      = false
-		hasNumber     = false
-		hasSpecial    = false
-		hasNotAllowed = false
-	)
+	// Users signing in through an external identity prove they own their email instead
+	if user.HashedPassword == "" {
+		return status.Errorf(codes.FailedPrecondition, "userID %s has no password, set one with a password reset first", id)
+	}
 
-	for _, char := range password {
-		switch {
-		case unicode.IsUpper(char):
-			hasUpper = true
-		case unicode.IsLower(char):
-			hasLower = true
-		case unicode.IsNumber(char):
-			hasNumber = true
-		case unicode.IsPunct(char) || unicode.IsSymbol(char):
-			hasSpecial = true
-		}
+	err = u.checkLoginAllowed(ctx, user, "")
+	if err != nil {
+		return err
+	}
 
-		if !unicode.IsLetter(char) || !unicode.IsNumber(char) || !unicode.IsPunct(char) || !unicode.IsSymbol(char) {
-			hasNotAllowed = true
-			break
-		}
+	if currentPassword == "" {
+		return status.Errorf(codes.PermissionDenied, "currentPassword is required")
 	}
 
-	if !hasNotAllowed {
-		return errors.New("password must only contain letter, numbers, or special characters")
-	} else if !hasUpper {
-		return errors.New("password must contain uppercase character")
-	} else if !hasLower {
-		return errors.New("password must contain lowercase character")
-	} else if !hasNumber {
-		return errors.New("password must contain a number")
-	} else if !hasSpecial {
-		return errors.New("password must contain a special character")
-	} else {
-		return nil
+	if !u.checkPassword(ctx, user, currentPassword) {
+		u.recordFailedLogin(ctx, user, "")
+		return status.Errorf(codes.PermissionDenied, "currentPassword is invalid")
+	}
+
+	return nil
+}
+
+// createToken stores token as a new single-use token expiring after ttl, and returns the
+// token and when it expires.
+func (u usersServerImpl) createToken(ctx context.Context, token daos.Token, ttl time.Duration) (string, time.Time, error) {
+	plainToken, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	token.TokenHash = tokenHash
+	token.CreatedAt = now
+	token.ExpiresAt = now.Add(ttl)
+
+	err = u.TokensDAO.CreateToken(ctx, token)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return plainToken, token.ExpiresAt, nil
+}
+
+func toPBUser(user daos.User) *pb.User {
+	return &pb.User{
+		Id:                      user.UserID,
+		Login:                   user.Login,
+		Email:                   user.Email,
+		CreatedAt:               timestamppb.New(user.CreatedAt),
+		UpdatedAt:               timestamppb.New(user.UpdatedAt),
+		EmailVerified:           user.EmailVerified,
+		TotpEnabled:             user.TOTPEnabled,
+		RecoveryCodesRemaining:  int32(len(user.RecoveryCodeHashes)),
+		WebAuthnCredentialCount: int32(len(user.WebAuthnCredentialIDs)),
+		ExternalIdentities:      toPBExternalIdentities(user.ExternalIdentities),
+		HasPassword:             user.HashedPassword != "",
+		Roles:                   user.Roles,
 	}
 }
 
@@ -142,39 +198,46 @@ func (u usersServerImpl) CreateUser(ctx context.Context, req *pb.CreateUserReque
 		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 
-	err = validatePassword(req.Password)
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "password invalid")
+	if req.Password == "" && req.ExternalIdentity == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "password is required without an external identity")
 	}
 
-	err = validateLogin(req.Login)
+	violations := u.LoginPolicy.Check(req.Login)
+	if req.Password != "" {
+		violations = append(violations, u.PasswordPolicy.Check(req.Password)...)
+	}
+	err = policyError(violations)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "login invalid")
+		return nil, err
 	}
 
-	// Check that login is not in use first
-	userByLogin, err := u.UsersDAO.GetUserByLogin(ctx, req.Login)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error checking if login already exists")
+	// Users signing in through an external identity don't need a password
+	var hashedPassword string
+	if req.Password != "" {
+		hashedPassword, err = u.hashPassword(req.Password)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if userByLogin != nil {
-		return nil, status.Errorf(codes.AlreadyExists, "a user with login %s already exists", req.Login)
+	var identities []daos.ExternalIdentity
+	if req.ExternalIdentity != nil {
+		identities = append(identities, toDAOExternalIdentity(req.ExternalIdentity))
 	}
 
-	newUser, err := u.UsersDAO.CreateUser(ctx, req.Login, req.Email, req.Password)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error creating user")
+	newUser, err := u.UsersDAO.CreateUser(ctx, req.Login, req.Email, hashedPassword, identities...)
+	if errors.Is(err, daos.ErrLoginTaken) {
+		return nil, status.Errorf(codes.AlreadyExists, "a user with login %s already exists", req.Login)
+	} else if errors.Is(err, daos.ErrEmailTaken) {
+		return nil, status.Errorf(codes.AlreadyExists, "a user with email %s already exists", req.Email)
+	} else if errors.Is(err, daos.ErrExternalIdentityTaken) {
+		return nil, status.Errorf(codes.AlreadyExists, "%s identity is already linked to another user", req.ExternalIdentity.Provider)
+	} else if err != nil {
+		return nil, daoError(err, "error creating user")
 	}
 
 	return &pb.CreateUserResponse{
-		User: &pb.User{
-			Id:        newUser.UserID,
-			Login:     newUser.Login,
-			Email:     newUser.Email,
-			CreatedAt: timestamppb.New(newUser.CreatedAt),
-			UpdatedAt: timestamppb.New(newUser.UpdatedAt),
-		},
+		User: toPBUser(newUser),
 	}, nil
 }
 
@@ -184,56 +247,142 @@ func (u usersServerImpl) GetUser(ctx context.Context, req *pb.GetUserRequest) (*
 		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 
-	var user *daos.User
-	if req.Id != "" {
-		user, err = u.UsersDAO.GetUserByID(ctx, req.Id)
-	} else if req.Login != "" {
-		user, err = u.UsersDAO.GetUserByLogin(ctx, req.Login)
+	user, err := u.findUser(ctx, req.Id, req.Login)
+	if errors.Is(err, daos.ErrNotFound) {
+		return &pb.GetUserResponse{}, nil
+	} else if err != nil {
+		return nil, daoError(err, "error getting user")
+	}
+
+	return &pb.GetUserResponse{
+		User: toPBUser(*user),
+	}, nil
+}
+
+func (u usersServerImpl) CheckUserPassword(ctx context.Context, req *pb.CheckUserPasswordRequest) (*pb.CheckUserPasswordResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	user, err := u.findUser(ctx, req.Id, req.Login)
+	if err != nil && !errors.Is(err, daos.ErrNotFound) {
+		return nil, daoError(err, "error getting user")
 	}
 
+	err = u.checkLoginAllowed(ctx, user, loginKey(req.Id, req.Login))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error getting user")
+		return nil, err
 	}
 
-	if user == nil {
-		return &pb.GetUserResponse{}, nil
+	// Unknown users fail like wrong passwords so logins can't be enumerated
+	if !u.checkPassword(ctx, user, req.Password) {
+		u.recordFailedLogin(ctx, user, loginKey(req.Id, req.Login))
+		return nil, status.Errorf(codes.Unauthenticated, "invalid login or password")
 	}
 
-	return &pb.GetUserResponse{
-		User: &pb.User{
-			Id:        user.UserID,
-			Login:     user.Login,
-			Email:     user.Email,
-			CreatedAt: timestamppb.New(user.CreatedAt),
-			UpdatedAt: timestamppb.New(user.UpdatedAt),
-		},
+	// Failures are only cleared once the second factor is verified too
+	if user.TOTPEnabled {
+		token, err := u.newSecondFactorToken(ctx, *user, false)
+		if err != nil {
+			return nil, daoError(err, "error creating second factor token")
+		}
+
+		return &pb.CheckUserPasswordResponse{
+			SecondFactorRequired: true,
+			SecondFactorToken:    token,
+		}, nil
+	}
+
+	u.recordSuccessfulLogin(ctx, *user)
+
+	return &pb.CheckUserPasswordResponse{
+		UserId: user.UserID,
 	}, nil
 }
 
-func (u usersServerImpl) CheckUserPassword(ctx context.Context, req *pb.CheckUserPasswordRequest) (*pb.CheckUserPasswordResponse, error) {
+func (u usersServerImpl) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
 	err := req.Validate()
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 
-	var user *daos.User
-	if req.Id != "" {
-		user, err = u.UsersDAO.GetUserByID(ctx, req.Id)
-	} else if req.Login != "" {
-		user, err = u.UsersDAO.GetUserByLogin(ctx, req.Login)
+	if len(req.UpdateMask.Paths) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "updateMask must contain at least one path")
+	}
+
+	var (
+		update         daos.UserUpdate
+		updatePassword bool
+		violations     policy.Violations
+	)
+	for _, path := range req.UpdateMask.Paths {
+		switch path {
+		case "login":
+			violations = append(violations, u.LoginPolicy.Check(req.Login)...)
+			update.Login = &req.Login
+		case "email":
+			if req.Email == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "email must not be empty")
+			}
+			update.Email = &req.Email
+		case "password":
+			violations = append(violations, u.PasswordPolicy.Check(req.Password)...)
+			updatePassword = true
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "updateMask path %s is not supported", path)
+		}
 	}
 
+	err = policyError(violations)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error getting user")
+		return nil, err
+	}
+
+	if updatePassword || update.Email != nil {
+		err = u.checkOwnPassword(ctx, req.Id, req.CurrentPassword)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if user == nil {
-		return nil, status.Errorf(codes.NotFound, "user for userID %s not found", user.UserID)
+	// Passwords are only hashed once every field is known to be valid
+	if updatePassword {
+		hashedPassword, err := u.hashPassword(req.Password)
+		if err != nil {
+			return nil, err
+		}
+		update.HashedPassword = &hashedPassword
+	}
+
+	user, err := u.UsersDAO.UpdateUser(ctx, req.Id, update)
+	if errors.Is(err, daos.ErrLoginTaken) {
+		return nil, status.Errorf(codes.AlreadyExists, "a user with login %s already exists", req.Login)
+	} else if errors.Is(err, daos.ErrEmailTaken) {
+		return nil, status.Errorf(codes.AlreadyExists, "a user with email %s already exists", req.Email)
+	} else if errors.Is(err, daos.ErrConcurrentModification) {
+		return nil, status.Errorf(codes.Aborted, "user was modified concurrently, try again")
+	} else if err != nil {
+		return nil, daoError(err, "error updating user")
+	}
+
+	return &pb.UpdateUserResponse{
+		User: toPBUser(*user),
+	}, nil
+}
+
+func (u usersServerImpl) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 
-	if !auth.CheckPasswordHash(user.HashedPassword, req.Password) {
-		return nil, status.Errorf(codes.InvalidArgument, "password does not match userID %s password", user.UserID)
+	_, err = u.UsersDAO.DeleteUser(ctx, req.Id, req.Purge)
+	if errors.Is(err, daos.ErrConcurrentModification) {
+		return nil, status.Errorf(codes.Aborted, "user was modified concurrently, try again")
+	} else if err != nil {
+		return nil, daoError(err, "error deleting user")
 	}
 
-	return &pb.CheckUserPasswordResponse{}, nil
+	return &pb.DeleteUserResponse{}, nil
 }
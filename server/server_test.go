@@ -0,0 +1,617 @@
+package server
+
+import (
+	"context"
+	"github.com/raidcomp/users-service/auth"
+	"github.com/raidcomp/users-service/clients"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"github.com/raidcomp/users-service/webauthn"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testPassword = "Raid3r!Password"
+
+var testTokens = newTestTokenIssuer()
+
+var testSecrets = newTestSecretBox()
+
+var testRelyingParty = &webauthn.RelyingParty{ID: "raidcomp.io", Name: "Raidcomp", Origins: []string{"https://raidcomp.io"}}
+
+// testPasswords hashes with cheap parameters to keep the tests fast.
+var testPasswords = auth.NewPasswordHasher(
+	auth.NewArgon2idHasher(auth.Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}),
+	auth.NewBcryptHasher(bcrypt.MinCost),
+)
+
+func newTestTokenIssuer() *auth.TokenIssuer {
+	keys, err := auth.NewEphemeralKeySet()
+	if err != nil {
+		panic(err)
+	}
+	return auth.NewTokenIssuer(keys)
+}
+
+// recordingMailer keeps the emails sent by tests.
+type recordingMailer struct {
+	mu                  sync.Mutex
+	verificationEmails  []clients.VerificationEmail
+	passwordResetEmails []clients.PasswordResetEmail
+}
+
+func (m *recordingMailer) SendVerificationEmail(ctx context.Context, email clients.VerificationEmail) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.verificationEmails = append(m.verificationEmails, email)
+	return nil
+}
+
+func (m *recordingMailer) SendPasswordResetEmail(ctx context.Context, email clients.PasswordResetEmail) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.passwordResetEmails = append(m.passwordResetEmails, email)
+	return nil
+}
+
+func newTestSecretBox() *auth.SecretBox {
+	secrets, err := auth.NewEphemeralSecretBox()
+	if err != nil {
+		panic(err)
+	}
+	return secrets
+}
+
+// newTestDependencies returns in-memory dependencies seeded with the given logins.
+// Seeded users have the email <login>@raidcomp.io and password testPassword, and
+// emails are kept by a *recordingMailer.
+func newTestDependencies(t *testing.T, logins ...string) (Dependencies, map[string]daos.User) {
+	t.Helper()
+
+	usersDAO := daos.NewInMemoryUsersDAO()
+	users := map[string]daos.User{}
+	hashedPassword, err := testPasswords.Hash(testPassword)
+	if err != nil {
+		t.Fatalf("hashing password: %v", err)
+	}
+
+	for _, login := range logins {
+		user, err := usersDAO.CreateUser(context.Background(), login, login+"@raidcomp.io", hashedPassword)
+		if err != nil {
+			t.Fatalf("seeding user %s: %v", login, err)
+		}
+		users[login] = user
+	}
+
+	return Dependencies{
+		UsersDAO:    usersDAO,
+		SessionsDAO: daos.NewInMemorySessionsDAO(),
+		TokensDAO:   daos.NewInMemoryTokensDAO(),
+		Tokens:      testTokens,
+		Passwords:   testPasswords,
+		Mailer:      &recordingMailer{},
+		Secrets:     testSecrets,
+		WebAuthn:    testRelyingParty,
+	}, users
+}
+
+// newTestServer returns a server with the dependencies of newTestDependencies.
+func newTestServer(t *testing.T, logins ...string) (pb.UsersServer, daos.UsersDAO, map[string]daos.User) {
+	t.Helper()
+
+	deps, users := newTestDependencies(t, logins...)
+	return NewUsersServer(deps), deps.UsersDAO, users
+}
+
+func assertCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+
+	if got := status.Code(err); got != want {
+		t.Fatalf("got code %v, want %v (err: %v)", got, want, err)
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.CreateUserRequest
+		want codes.Code
+	}{
+		{
+			name: "login too short",
+			req:  &pb.CreateUserRequest{Login: "raid", Email: "raider@raidcomp.io", Password: testPassword},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "login too long",
+			req:  &pb.CreateUserRequest{Login: "raiderraiderraiderraiderraider", Email: "raider@raidcomp.io", Password: testPassword},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "invalid email",
+			req:  &pb.CreateUserRequest{Login: "raider", Email: "raider", Password: testPassword},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "password too short",
+			req:  &pb.CreateUserRequest{Login: "raider", Email: "raider@raidcomp.io", Password: "R4id!"},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "login with separators",
+			req:  &pb.CreateUserRequest{Login: "raid.er_1", Email: "raider@raidcomp.io", Password: testPassword},
+			want: codes.OK,
+		},
+		{
+			name: "no password without external identity",
+			req:  &pb.CreateUserRequest{Login: "raider", Email: "raider@raidcomp.io"},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "no password with external identity",
+			req:  &pb.CreateUserRequest{Login: "raider", Email: "raider@raidcomp.io", ExternalIdentity: discordIdentity("1234")},
+			want: codes.OK,
+		},
+		{
+			name: "login taken",
+			req:  &pb.CreateUserRequest{Login: "Healer", Email: "raider@raidcomp.io", Password: testPassword},
+			want: codes.AlreadyExists,
+		},
+		{
+			name: "email taken",
+			req:  &pb.CreateUserRequest{Login: "raider", Email: "HEALER@raidcomp.io", Password: testPassword},
+			want: codes.AlreadyExists,
+		},
+		{
+			name: "login mixing scripts",
+			// With a cyrillic "а"
+			req:  &pb.CreateUserRequest{Login: "r\u0430ider", Email: "raider@raidcomp.io", Password: testPassword},
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usersServer, _, _ := newTestServer(t, "healer")
+
+			resp, err := usersServer.CreateUser(context.Background(), tt.req)
+			assertCode(t, err, tt.want)
+
+			if tt.want != codes.OK {
+				return
+			}
+			if resp.User.Login != tt.req.Login || resp.User.Email != tt.req.Email {
+				t.Errorf("got user %s <%s>, want %s <%s>", resp.User.Login, resp.User.Email, tt.req.Login, tt.req.Email)
+			}
+			if resp.User.HasPassword != (tt.req.Password != "") {
+				t.Errorf("got hasPassword %v for password %q", resp.User.HasPassword, tt.req.Password)
+			}
+			if tt.req.ExternalIdentity != nil && len(resp.User.ExternalIdentities) != 1 {
+				t.Errorf("got identities %v, want the request's identity", resp.User.ExternalIdentities)
+			}
+		})
+	}
+}
+
+func TestCreateUserReturnsEveryViolation(t *testing.T) {
+	usersServer, _, _ := newTestServer(t)
+
+	_, err := usersServer.CreateUser(context.Background(), &pb.CreateUserRequest{Login: "_raid", Email: "raider@raidcomp.io", Password: "raider"})
+	assertCode(t, err, codes.InvalidArgument)
+
+	var fields []string
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				fields = append(fields, violation.Field)
+			}
+		}
+	}
+
+	// The login is too short and starts with an underscore, the password is too short and
+	// lacks an uppercase letter, a number and a special character
+	want := []string{"login", "login", "password", "password", "password", "password"}
+	if strings.Join(fields, ",") != strings.Join(want, ",") {
+		t.Errorf("got violations of %v, want %v (err: %v)", fields, want, err)
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       func(users map[string]daos.User) *pb.GetUserRequest
+		wantLogin string
+	}{
+		{
+			name: "by id",
+			req: func(users map[string]daos.User) *pb.GetUserRequest {
+				return &pb.GetUserRequest{Id: users["raider"].UserID}
+			},
+			wantLogin: "raider",
+		},
+		{
+			name: "by login",
+			req: func(users map[string]daos.User) *pb.GetUserRequest {
+				return &pb.GetUserRequest{Login: "healer"}
+			},
+			wantLogin: "healer",
+		},
+		{
+			name: "id takes precedence over login",
+			req: func(users map[string]daos.User) *pb.GetUserRequest {
+				return &pb.GetUserRequest{Id: users["raider"].UserID, Login: "healer"}
+			},
+			wantLogin: "raider",
+		},
+		{
+			name: "unknown id",
+			req: func(users map[string]daos.User) *pb.GetUserRequest {
+				return &pb.GetUserRequest{Id: "unknown"}
+			},
+		},
+		{
+			name: "login ignores case",
+			req: func(users map[string]daos.User) *pb.GetUserRequest {
+				return &pb.GetUserRequest{Login: "Raider"}
+			},
+			wantLogin: "raider",
+		},
+		{
+			name: "login ignores fullwidth letters",
+			req: func(users map[string]daos.User) *pb.GetUserRequest {
+				return &pb.GetUserRequest{Login: "ｒａｉｄｅｒ"}
+			},
+			wantLogin: "raider",
+		},
+		{
+			name: "login ignores look-alike letters",
+			req: func(users map[string]daos.User) *pb.GetUserRequest {
+				// With a cyrillic "а" and "е"
+				return &pb.GetUserRequest{Login: "r\u0430id\u0435r"}
+			},
+			wantLogin: "raider",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usersServer, _, users := newTestServer(t, "raider", "healer")
+
+			resp, err := usersServer.GetUser(context.Background(), tt.req(users))
+			assertCode(t, err, codes.OK)
+
+			if resp.User.GetLogin() != tt.wantLogin {
+				t.Errorf("got login %q, want %q", resp.User.GetLogin(), tt.wantLogin)
+			}
+			if tt.wantLogin != "" && resp.User.Id != users[tt.wantLogin].UserID {
+				t.Errorf("got id %s, want %s", resp.User.Id, users[tt.wantLogin].UserID)
+			}
+		})
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		email     string
+		paths     []string
+		want      codes.Code
+		wantEmail string
+	}{
+		{
+			name:      "update email",
+			email:     "new-raider@raidcomp.io",
+			paths:     []string{"email"},
+			want:      codes.OK,
+			wantEmail: "new-raider@raidcomp.io",
+		},
+		{
+			name:      "update to own email",
+			email:     "raider@raidcomp.io",
+			paths:     []string{"email"},
+			want:      codes.OK,
+			wantEmail: "raider@raidcomp.io",
+		},
+		{
+			name:  "email in use",
+			email: "healer@raidcomp.io",
+			paths: []string{"email"},
+			want:  codes.AlreadyExists,
+		},
+		{
+			name:  "email in use with another case",
+			email: "Healer@RaidComp.io",
+			paths: []string{"email"},
+			want:  codes.AlreadyExists,
+		},
+		{
+			name:      "update case of own email",
+			email:     "Raider@RaidComp.io",
+			paths:     []string{"email"},
+			want:      codes.OK,
+			wantEmail: "Raider@RaidComp.io",
+		},
+		{
+			name:  "invalid email",
+			email: "raider",
+			paths: []string{"email"},
+			want:  codes.InvalidArgument,
+		},
+		{
+			name:  "empty email in mask",
+			paths: []string{"email"},
+			want:  codes.InvalidArgument,
+		},
+		{
+			name:  "empty mask",
+			email: "new-raider@raidcomp.io",
+			want:  codes.InvalidArgument,
+		},
+		{
+			name:  "unsupported path",
+			email: "new-raider@raidcomp.io",
+			paths: []string{"createdAt"},
+			want:  codes.InvalidArgument,
+		},
+		{
+			name:  "unknown user",
+			id:    "unknown",
+			email: "new-raider@raidcomp.io",
+			paths: []string{"email"},
+			want:  codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usersServer, usersDAO, users := newTestServer(t, "raider", "healer")
+
+			id := tt.id
+			if id == "" {
+				id = users["raider"].UserID
+			}
+
+			resp, err := usersServer.UpdateUser(context.Background(), &pb.UpdateUserRequest{
+				Id:         id,
+				Email:      tt.email,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: tt.paths},
+			})
+			assertCode(t, err, tt.want)
+			if tt.want != codes.OK {
+				return
+			}
+
+			if resp.User.Email != tt.wantEmail {
+				t.Errorf("got email %q, want %q", resp.User.Email, tt.wantEmail)
+			}
+			if !resp.User.UpdatedAt.AsTime().After(users["raider"].UpdatedAt) {
+				t.Errorf("updatedAt was not bumped")
+			}
+
+			stored, _ := usersDAO.GetUserByID(context.Background(), id)
+			if stored.Email != tt.wantEmail {
+				t.Errorf("got stored email %q, want %q", stored.Email, tt.wantEmail)
+			}
+		})
+	}
+}
+
+func TestUpdateUserForThemselves(t *testing.T) {
+	usersServer, usersDAO, users := newTestServer(t, "raider")
+	raider := users["raider"]
+	ctx := selfContext(raider.UserID)
+
+	// Logins can be changed with the access token alone
+	_, err := usersServer.UpdateUser(ctx, &pb.UpdateUserRequest{
+		Id:         raider.UserID,
+		Login:      "raider_two",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"login"}},
+	})
+	assertCode(t, err, codes.OK)
+
+	for _, currentPassword := range []string{"", "Wr0ng!Password"} {
+		_, err = usersServer.UpdateUser(ctx, &pb.UpdateUserRequest{
+			Id:              raider.UserID,
+			Email:           "taken.over@example.com",
+			CurrentPassword: currentPassword,
+			UpdateMask:      &fieldmaskpb.FieldMask{Paths: []string{"email"}},
+		})
+		assertCode(t, err, codes.PermissionDenied)
+	}
+
+	stored, _ := usersDAO.GetUserByID(ctx, raider.UserID)
+	if stored.Email != raider.Email || stored.FailedLoginAttempts != 1 {
+		t.Errorf("got email %s and %d failed attempts, want %s and 1", stored.Email, stored.FailedLoginAttempts, raider.Email)
+	}
+
+	_, err = usersServer.UpdateUser(ctx, &pb.UpdateUserRequest{
+		Id:              raider.UserID,
+		Email:           "raider.two@example.com",
+		Password:        "N3w!Password",
+		CurrentPassword: testPassword,
+		UpdateMask:      &fieldmaskpb.FieldMask{Paths: []string{"email", "password"}},
+	})
+	assertCode(t, err, codes.OK)
+}
+
+func TestDeleteUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("soft delete hides user but keeps email reserved", func(t *testing.T) {
+		usersServer, _, users := newTestServer(t, "raider", "healer")
+		raider := users["raider"]
+
+		_, err := usersServer.DeleteUser(ctx, &pb.DeleteUserRequest{Id: raider.UserID})
+		assertCode(t, err, codes.OK)
+
+		resp, err := usersServer.GetUser(ctx, &pb.GetUserRequest{Id: raider.UserID})
+		assertCode(t, err, codes.OK)
+		if resp.User != nil {
+			t.Errorf("deleted user was returned by id")
+		}
+
+		resp, err = usersServer.GetUser(ctx, &pb.GetUserRequest{Login: raider.Login})
+		assertCode(t, err, codes.OK)
+		if resp.User != nil {
+			t.Errorf("deleted user was returned by login")
+		}
+
+		_, err = usersServer.UpdateUser(ctx, &pb.UpdateUserRequest{
+			Id:         users["healer"].UserID,
+			Email:      raider.Email,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"email"}},
+		})
+		assertCode(t, err, codes.AlreadyExists)
+
+		_, err = usersServer.DeleteUser(ctx, &pb.DeleteUserRequest{Id: raider.UserID})
+		assertCode(t, err, codes.NotFound)
+	})
+
+	t.Run("purge frees email", func(t *testing.T) {
+		usersServer, _, users := newTestServer(t, "raider", "healer")
+		raider := users["raider"]
+
+		_, err := usersServer.DeleteUser(ctx, &pb.DeleteUserRequest{Id: raider.UserID})
+		assertCode(t, err, codes.OK)
+
+		_, err = usersServer.DeleteUser(ctx, &pb.DeleteUserRequest{Id: raider.UserID, Purge: true})
+		assertCode(t, err, codes.OK)
+
+		_, err = usersServer.UpdateUser(ctx, &pb.UpdateUserRequest{
+			Id:         users["healer"].UserID,
+			Email:      raider.Email,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"email"}},
+		})
+		assertCode(t, err, codes.OK)
+
+		_, err = usersServer.DeleteUser(ctx, &pb.DeleteUserRequest{Id: raider.UserID, Purge: true})
+		assertCode(t, err, codes.NotFound)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		usersServer, _, _ := newTestServer(t)
+
+		_, err := usersServer.DeleteUser(ctx, &pb.DeleteUserRequest{Id: "unknown"})
+		assertCode(t, err, codes.NotFound)
+	})
+}
+
+func TestCheckUserPassword(t *testing.T) {
+	tests := []struct {
+		name string
+		req  func(users map[string]daos.User) *pb.CheckUserPasswordRequest
+		want codes.Code
+	}{
+		{
+			name: "correct password by id",
+			req: func(users map[string]daos.User) *pb.CheckUserPasswordRequest {
+				return &pb.CheckUserPasswordRequest{Id: users["raider"].UserID, Password: testPassword}
+			},
+			want: codes.OK,
+		},
+		{
+			name: "correct password by login",
+			req: func(users map[string]daos.User) *pb.CheckUserPasswordRequest {
+				return &pb.CheckUserPasswordRequest{Login: "raider", Password: testPassword}
+			},
+			want: codes.OK,
+		},
+		{
+			name: "wrong password",
+			req: func(users map[string]daos.User) *pb.CheckUserPasswordRequest {
+				return &pb.CheckUserPasswordRequest{Login: "raider", Password: "Wr0ng!Password"}
+			},
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "another user's password",
+			req: func(users map[string]daos.User) *pb.CheckUserPasswordRequest {
+				return &pb.CheckUserPasswordRequest{Id: users["healer"].UserID, Password: "Wr0ng!Password"}
+			},
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "unknown login",
+			req: func(users map[string]daos.User) *pb.CheckUserPasswordRequest {
+				return &pb.CheckUserPasswordRequest{Login: "unknown", Password: testPassword}
+			},
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "unknown id",
+			req: func(users map[string]daos.User) *pb.CheckUserPasswordRequest {
+				return &pb.CheckUserPasswordRequest{Id: "unknown", Password: testPassword}
+			},
+			want: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usersServer, _, users := newTestServer(t, "raider", "healer")
+
+			req := tt.req(users)
+			resp, err := usersServer.CheckUserPassword(context.Background(), req)
+			assertCode(t, err, tt.want)
+
+			if err == nil && resp.UserId != users["raider"].UserID {
+				t.Errorf("got userId %s, want %s", resp.UserId, users["raider"].UserID)
+			}
+		})
+	}
+}
+
+func TestCheckUserPasswordDoesNotRevealUsers(t *testing.T) {
+	ctx := context.Background()
+	usersServer, _, _ := newTestServer(t, "raider")
+
+	_, wrongPassword := usersServer.CheckUserPassword(ctx, &pb.CheckUserPasswordRequest{Login: "raider", Password: "Wr0ng!Password"})
+	_, unknownUser := usersServer.CheckUserPassword(ctx, &pb.CheckUserPasswordRequest{Login: "unknown", Password: "Wr0ng!Password"})
+
+	if status.Code(wrongPassword) != status.Code(unknownUser) || status.Convert(wrongPassword).Message() != status.Convert(unknownUser).Message() {
+		t.Errorf("got %v for a wrong password and %v for an unknown user, want the same error", wrongPassword, unknownUser)
+	}
+}
+
+func TestCheckUserPasswordRehashesOutdatedHash(t *testing.T) {
+	ctx := context.Background()
+	usersServer, usersDAO, users := newTestServer(t, "raider")
+	raider := users["raider"]
+
+	bcryptHash, err := auth.NewBcryptHasher(bcrypt.MinCost).Hash(testPassword)
+	if err != nil {
+		t.Fatalf("hashing password: %v", err)
+	}
+	_, err = usersDAO.UpdateUser(ctx, raider.UserID, daos.UserUpdate{HashedPassword: &bcryptHash})
+	if err != nil {
+		t.Fatalf("updating password hash: %v", err)
+	}
+
+	_, err = usersServer.CheckUserPassword(ctx, &pb.CheckUserPasswordRequest{Id: raider.UserID, Password: "Wr0ng!Password"})
+	assertCode(t, err, codes.Unauthenticated)
+
+	stored, _ := usersDAO.GetUserByID(ctx, raider.UserID)
+	if stored.HashedPassword != bcryptHash {
+		t.Errorf("hash was replaced after a failed check")
+	}
+
+	_, err = usersServer.CheckUserPassword(ctx, &pb.CheckUserPasswordRequest{Id: raider.UserID, Password: testPassword})
+	assertCode(t, err, codes.OK)
+
+	stored, _ = usersDAO.GetUserByID(ctx, raider.UserID)
+	if !strings.HasPrefix(stored.HashedPassword, "$argon2id$") {
+		t.Errorf("got hash %s, want an argon2id hash", stored.HashedPassword)
+	}
+
+	_, err = usersServer.CheckUserPassword(ctx, &pb.CheckUserPasswordRequest{Id: raider.UserID, Password: testPassword})
+	assertCode(t, err, codes.OK)
+}
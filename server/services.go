@@ -0,0 +1,111 @@
+package server
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// serviceNamePattern matches the names of services, as used in their service:<name> role.
+var serviceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Service is an internal service authenticated by its TLS client certificate.
+type Service struct {
+	Name string `json:"name"`
+	// SANs are the DNS names and URIs found in the service's client certificates, e.g.
+	// raids.raidcomp.internal or spiffe://raidcomp.io/raids.
+	SANs []string `json:"sans"`
+	// Methods are the methods the service may call besides public ones, e.g. GetUser.
+	Methods []string `json:"methods"`
+}
+
+// LoadServices reads the services allowed to call the server from a JSON file of the form
+// {"services": [{"name": "raids", "sans": [...], "methods": [...]}]}.
+func LoadServices(path string) ([]Service, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Services []Service `json:"services"`
+	}
+	err = json.Unmarshal(bytes, &file)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return file.Services, nil
+}
+
+// serviceRegistry finds services by the SANs of their client certificates.
+type serviceRegistry struct {
+	bySAN   map[string]*Service
+	methods map[string]map[string]bool
+}
+
+// newServiceRegistry checks that every service has a unique name and SANs, and only lists
+// methods their policy lets services call.
+func newServiceRegistry(services []Service, policies map[string]MethodPolicy) (*serviceRegistry, error) {
+	registry := &serviceRegistry{
+		bySAN:   map[string]*Service{},
+		methods: map[string]map[string]bool{},
+	}
+
+	for i := range services {
+		service := &services[i]
+		if !serviceNamePattern.MatchString(service.Name) {
+			return nil, fmt.Errorf("invalid service name %q", service.Name)
+		}
+		if registry.methods[service.Name] != nil {
+			return nil, fmt.Errorf("service %s is listed twice", service.Name)
+		}
+		if len(service.SANs) == 0 {
+			return nil, fmt.Errorf("service %s has no SANs", service.Name)
+		}
+
+		for _, san := range service.SANs {
+			if other, ok := registry.bySAN[san]; ok {
+				return nil, fmt.Errorf("SAN %s is used by services %s and %s", san, other.Name, service.Name)
+			}
+			registry.bySAN[san] = service
+		}
+
+		methods := map[string]bool{}
+		for _, method := range service.Methods {
+			policy, ok := policies[method]
+			if !ok {
+				return nil, fmt.Errorf("service %s lists unknown method %s", service.Name, method)
+			}
+			if !policy.Public && !policy.grantsRole(Identity{Roles: []string{RoleServicePrefix + service.Name}}) {
+				return nil, fmt.Errorf("service %s lists %s, which services can't call", service.Name, method)
+			}
+			methods[method] = true
+		}
+		registry.methods[service.Name] = methods
+	}
+
+	return registry, nil
+}
+
+// find returns the service a client certificate belongs to by its DNS and URI SANs.
+func (r *serviceRegistry) find(cert *x509.Certificate) (*Service, bool) {
+	for _, name := range cert.DNSNames {
+		if service, ok := r.bySAN[name]; ok {
+			return service, true
+		}
+	}
+	for _, uri := range cert.URIs {
+		if service, ok := r.bySAN[uri.String()]; ok {
+			return service, true
+		}
+	}
+	return nil, false
+}
+
+// allows reports whether the service is allowed to call method.
+func (r *serviceRegistry) allows(service, method string) bool {
+	return r.methods[service][method]
+}
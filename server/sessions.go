@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/raidcomp/users-service/auth"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log"
+	"time"
+)
+
+// newSession mints an access token and a refresh token for user. The refresh token is
+// stored by the caller, either as a new session or by rotating an existing one.
+func (u usersServerImpl) newSession(user daos.User) (*pb.Session, daos.Session, error) {
+	accessToken, accessTokenExpiresAt, err := u.Tokens.IssueAccessToken(user.UserID, user.Login, user.Roles)
+	if err != nil {
+		return nil, daos.Session{}, err
+	}
+
+	refreshToken, refreshTokenHash, err := u.Tokens.NewRefreshToken()
+	if err != nil {
+		return nil, daos.Session{}, err
+	}
+
+	now := time.Now()
+	session := daos.Session{
+		TokenHash: refreshTokenHash,
+		UserID:    user.UserID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(u.Tokens.RefreshTokenTTL),
+	}
+
+	return &pb.Session{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  timestamppb.New(accessTokenExpiresAt),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(session.ExpiresAt),
+	}, session, nil
+}
+
+// createSession mints and stores a new session for user, returning a status error on failure.
+func (u usersServerImpl) createSession(ctx context.Context, user daos.User) (*pb.Session, error) {
+	session, storedSession, err := u.newSession(user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error creating session")
+	}
+
+	err = u.SessionsDAO.CreateSession(ctx, storedSession)
+	if err != nil {
+		return nil, daoError(err, "error creating session")
+	}
+
+	return session, nil
+}
+
+// sessionRevoked reports whether session was created before the user's sessions were revoked.
+func sessionRevoked(user daos.User, session daos.Session) bool {
+	return user.SessionsRevokedAt != nil && !session.CreatedAt.After(*user.SessionsRevokedAt)
+}
+
+func (u usersServerImpl) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	user, err := u.findUser(ctx, req.Id, req.Login)
+	if err != nil && !errors.Is(err, daos.ErrNotFound) {
+		return nil, daoError(err, "error getting user")
+	}
+
+	err = u.checkLoginAllowed(ctx, user, loginKey(req.Id, req.Login))
+	if err != nil {
+		return nil, err
+	}
+
+	if !u.checkPassword(ctx, user, req.Password) {
+		u.recordFailedLogin(ctx, user, loginKey(req.Id, req.Login))
+		return nil, status.Errorf(codes.Unauthenticated, "invalid login or password")
+	}
+
+	// Failures are only cleared once the second factor is verified too
+	if user.TOTPEnabled {
+		token, err := u.newSecondFactorToken(ctx, *user, true)
+		if err != nil {
+			return nil, daoError(err, "error creating second factor token")
+		}
+
+		return &pb.LoginResponse{
+			SecondFactorRequired: true,
+			SecondFactorToken:    token,
+		}, nil
+	}
+
+	u.recordSuccessfulLogin(ctx, *user)
+
+	session, err := u.createSession(ctx, *user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LoginResponse{
+		User:    toPBUser(*user),
+		Session: session,
+	}, nil
+}
+
+func (u usersServerImpl) RefreshSession(ctx context.Context, req *pb.RefreshSessionRequest) (*pb.RefreshSessionResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	tokenHash := auth.HashToken(req.RefreshToken)
+	storedSession, err := u.SessionsDAO.GetSession(ctx, tokenHash)
+	if errors.Is(err, daos.ErrNotFound) {
+		return nil, status.Errorf(codes.Unauthenticated, "refresh token is invalid or expired")
+	} else if err != nil {
+		return nil, daoError(err, "error getting session")
+	}
+
+	if time.Now().After(storedSession.ExpiresAt) {
+		return nil, status.Errorf(codes.Unauthenticated, "refresh token is invalid or expired")
+	}
+
+	user, err := u.UsersDAO.GetUserByID(ctx, storedSession.UserID)
+	if err != nil && !errors.Is(err, daos.ErrNotFound) {
+		return nil, daoError(err, "error getting user")
+	}
+
+	// Sessions of deleted users, or revoked e.g. by a password reset, are of no use anymore
+	if user == nil || sessionRevoked(*user, *storedSession) {
+		_, err = u.SessionsDAO.DeleteSession(ctx, tokenHash)
+		if err != nil && !errors.Is(err, daos.ErrNotFound) {
+			log.Printf("error deleting revoked session of user %s: %v", storedSession.UserID, err)
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "refresh token is invalid or expired")
+	}
+
+	session, newStoredSession, err := u.newSession(*user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error creating session")
+	}
+
+	rotated, err := u.SessionsDAO.RotateSession(ctx, tokenHash, newStoredSession)
+	if err != nil {
+		return nil, daoError(err, "error rotating session")
+	}
+
+	if !rotated {
+		// Another request rotated or revoked this refresh token in the meantime
+		return nil, status.Errorf(codes.Unauthenticated, "refresh token is invalid or expired")
+	}
+
+	return &pb.RefreshSessionResponse{
+		Session: session,
+	}, nil
+}
+
+func (u usersServerImpl) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	// Logging out of an unknown session is not an error
+	_, err = u.SessionsDAO.DeleteSession(ctx, auth.HashToken(req.RefreshToken))
+	if err != nil && !errors.Is(err, daos.ErrNotFound) {
+		return nil, daoError(err, "error deleting session")
+	}
+
+	return &pb.LogoutResponse{}, nil
+}
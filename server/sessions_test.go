@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"testing"
+)
+
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       bool
+		login    string
+		password string
+		want     codes.Code
+	}{
+		{name: "by login", login: "raider", password: testPassword, want: codes.OK},
+		{name: "by id", id: true, password: testPassword, want: codes.OK},
+		{name: "wrong password", login: "raider", password: "Wr0ng!Password", want: codes.Unauthenticated},
+		{name: "unknown login", login: "unknown", password: testPassword, want: codes.Unauthenticated},
+		{name: "no login or id", password: testPassword, want: codes.Unauthenticated},
+		{name: "empty password", login: "raider", want: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usersServer, _, users := newTestServer(t, "raider")
+
+			req := &pb.LoginRequest{Login: tt.login, Password: tt.password}
+			if tt.id {
+				req.Id = users["raider"].UserID
+			}
+
+			resp, err := usersServer.Login(context.Background(), req)
+			assertCode(t, err, tt.want)
+			if tt.want != codes.OK {
+				return
+			}
+
+			if resp.User.Id != users["raider"].UserID {
+				t.Errorf("got user %s, want %s", resp.User.Id, users["raider"].UserID)
+			}
+
+			claims, err := testTokens.ParseAccessToken(resp.Session.AccessToken)
+			if err != nil {
+				t.Fatalf("parsing access token: %v", err)
+			}
+			if claims.Subject != users["raider"].UserID || claims.Login != "raider" {
+				t.Errorf("got claims sub=%s login=%s", claims.Subject, claims.Login)
+			}
+			if resp.Session.RefreshToken == "" {
+				t.Errorf("no refresh token returned")
+			}
+		})
+	}
+}
+
+func TestRefreshSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rotates refresh token", func(t *testing.T) {
+		usersServer, _, _ := newTestServer(t, "raider")
+
+		login, err := usersServer.Login(ctx, &pb.LoginRequest{Login: "raider", Password: testPassword})
+		assertCode(t, err, codes.OK)
+
+		refreshed, err := usersServer.RefreshSession(ctx, &pb.RefreshSessionRequest{RefreshToken: login.Session.RefreshToken})
+		assertCode(t, err, codes.OK)
+		if refreshed.Session.RefreshToken == login.Session.RefreshToken {
+			t.Errorf("refresh token was not rotated")
+		}
+		if _, err := testTokens.ParseAccessToken(refreshed.Session.AccessToken); err != nil {
+			t.Errorf("parsing access token: %v", err)
+		}
+
+		_, err = usersServer.RefreshSession(ctx, &pb.RefreshSessionRequest{RefreshToken: login.Session.RefreshToken})
+		assertCode(t, err, codes.Unauthenticated)
+
+		_, err = usersServer.RefreshSession(ctx, &pb.RefreshSessionRequest{RefreshToken: refreshed.Session.RefreshToken})
+		assertCode(t, err, codes.OK)
+	})
+
+	t.Run("unknown refresh token", func(t *testing.T) {
+		usersServer, _, _ := newTestServer(t)
+
+		_, err := usersServer.RefreshSession(ctx, &pb.RefreshSessionRequest{RefreshToken: "unknown"})
+		assertCode(t, err, codes.Unauthenticated)
+	})
+
+	t.Run("deleted user", func(t *testing.T) {
+		usersServer, _, users := newTestServer(t, "raider")
+
+		login, err := usersServer.Login(ctx, &pb.LoginRequest{Login: "raider", Password: testPassword})
+		assertCode(t, err, codes.OK)
+
+		_, err = usersServer.DeleteUser(ctx, &pb.DeleteUserRequest{Id: users["raider"].UserID})
+		assertCode(t, err, codes.OK)
+
+		_, err = usersServer.RefreshSession(ctx, &pb.RefreshSessionRequest{RefreshToken: login.Session.RefreshToken})
+		assertCode(t, err, codes.Unauthenticated)
+	})
+
+	t.Run("revoked sessions", func(t *testing.T) {
+		usersServer, usersDAO, users := newTestServer(t, "raider")
+
+		login, err := usersServer.Login(ctx, &pb.LoginRequest{Login: "raider", Password: testPassword})
+		assertCode(t, err, codes.OK)
+
+		_, err = usersDAO.UpdateUser(ctx, users["raider"].UserID, daos.UserUpdate{RevokeSessions: true})
+		if err != nil {
+			t.Fatalf("revoking sessions: %v", err)
+		}
+
+		_, err = usersServer.RefreshSession(ctx, &pb.RefreshSessionRequest{RefreshToken: login.Session.RefreshToken})
+		assertCode(t, err, codes.Unauthenticated)
+
+		login, err = usersServer.Login(ctx, &pb.LoginRequest{Login: "raider", Password: testPassword})
+		assertCode(t, err, codes.OK)
+
+		_, err = usersServer.RefreshSession(ctx, &pb.RefreshSessionRequest{RefreshToken: login.Session.RefreshToken})
+		assertCode(t, err, codes.OK)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	ctx := context.Background()
+	usersServer, _, _ := newTestServer(t, "raider")
+
+	login, err := usersServer.Login(ctx, &pb.LoginRequest{Login: "raider", Password: testPassword})
+	assertCode(t, err, codes.OK)
+
+	_, err = usersServer.Logout(ctx, &pb.LogoutRequest{RefreshToken: login.Session.RefreshToken})
+	assertCode(t, err, codes.OK)
+
+	_, err = usersServer.RefreshSession(ctx, &pb.RefreshSessionRequest{RefreshToken: login.Session.RefreshToken})
+	assertCode(t, err, codes.Unauthenticated)
+
+	// Logging out twice is not an error
+	_, err = usersServer.Logout(ctx, &pb.LogoutRequest{RefreshToken: login.Session.RefreshToken})
+	assertCode(t, err, codes.OK)
+}
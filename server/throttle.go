@@ -0,0 +1,332 @@
+package server
+
+import (
+	"context"
+	"github.com/raidcomp/users-service/daos"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"log"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LockoutPolicy allows MaxFailures failed password checks, after which every further
+// failure blocks checks for BaseDelay, doubling with each failure up to MaxDelay.
+type LockoutPolicy struct {
+	MaxFailures int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultAccountLockout locks accounts after a handful of wrong passwords.
+var DefaultAccountLockout = LockoutPolicy{
+	MaxFailures: 5,
+	BaseDelay:   time.Minute,
+	MaxDelay:    time.Hour,
+}
+
+// DefaultAddressThrottle is more lenient as many users can share an address.
+var DefaultAddressThrottle = LockoutPolicy{
+	MaxFailures: 20,
+	BaseDelay:   10 * time.Second,
+	MaxDelay:    15 * time.Minute,
+}
+
+// delay returns how long to block checks after the given number of consecutive failures.
+func (p LockoutPolicy) delay(failures int) time.Duration {
+	if failures < p.MaxFailures {
+		return 0
+	}
+
+	exponent := float64(failures - p.MaxFailures)
+	delay := float64(p.BaseDelay) * math.Pow(2, exponent)
+	if delay > float64(p.MaxDelay) {
+		return p.MaxDelay
+	}
+
+	return time.Duration(delay)
+}
+
+type keyFailures struct {
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time
+}
+
+// maxFailureKeys bounds the memory of a failureCounter, as anyone can make up new logins
+// and addresses to fail with.
+const maxFailureKeys = 100000
+
+// failureCounter counts consecutive failures per key in memory, for at most maxKeys keys.
+type failureCounter struct {
+	mu        sync.Mutex
+	keys      map[string]*keyFailures
+	maxKeys   int
+	lastPrune time.Time
+}
+
+func newFailureCounter(maxKeys int) *failureCounter {
+	return &failureCounter{keys: map[string]*keyFailures{}, maxKeys: maxKeys}
+}
+
+// blockedFor returns how long key is still blocked for.
+func (c *failureCounter) blockedFor(key string) time.Duration {
+	if key == "" {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	failures, ok := c.keys[key]
+	if !ok {
+		return 0
+	}
+
+	return time.Until(failures.blockedUntil)
+}
+
+// record counts a failure of key, blocking it as long as policy says.
+func (c *failureCounter) record(key string, policy LockoutPolicy) {
+	if key == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.prune(now, policy.MaxDelay)
+
+	failures, ok := c.keys[key]
+	if !ok {
+		if len(c.keys) >= c.maxKeys {
+			c.evictOldest()
+		}
+		failures = &keyFailures{}
+		c.keys[key] = failures
+	}
+
+	failures.failures++
+	failures.lastFailure = now
+	failures.blockedUntil = now.Add(policy.delay(failures.failures))
+}
+
+// prune forgets keys that haven't failed for longer than maxDelay, at most once a minute.
+// Callers must hold c.mu.
+func (c *failureCounter) prune(now time.Time, maxDelay time.Duration) {
+	if now.Sub(c.lastPrune) < time.Minute {
+		return
+	}
+	c.lastPrune = now
+
+	for key, failures := range c.keys {
+		if now.Sub(failures.lastFailure) > maxDelay {
+			delete(c.keys, key)
+		}
+	}
+}
+
+// evictOldest forgets the key that failed longest ago. Callers must hold c.mu.
+func (c *failureCounter) evictOldest() {
+	var (
+		oldestKey  string
+		oldestTime time.Time
+	)
+	for key, failures := range c.keys {
+		if oldestKey == "" || failures.lastFailure.Before(oldestTime) {
+			oldestKey, oldestTime = key, failures.lastFailure
+		}
+	}
+	delete(c.keys, oldestKey)
+}
+
+// LoginThrottle slows down password guessing. Failures are tracked per account on the
+// user record, locking the account, and per source address in memory, throttling the address.
+// Failures of logins no user has are tracked in memory and lock them like accounts, so
+// lockouts don't tell which logins exist.
+type LoginThrottle struct {
+	AccountLockout  LockoutPolicy
+	AddressThrottle LockoutPolicy
+	// TrustForwardedFor uses the first x-forwarded-for metadata address as the source
+	// address. Only enable it behind a proxy that sets the header.
+	TrustForwardedFor bool
+
+	addresses     *failureCounter
+	unknownLogins *failureCounter
+}
+
+func NewLoginThrottle(accountLockout, addressThrottle LockoutPolicy, trustForwardedFor bool) *LoginThrottle {
+	return &LoginThrottle{
+		AccountLockout:    accountLockout,
+		AddressThrottle:   addressThrottle,
+		TrustForwardedFor: trustForwardedFor,
+		addresses:         newFailureCounter(maxFailureKeys),
+		unknownLogins:     newFailureCounter(maxFailureKeys),
+	}
+}
+
+// loginKey returns the key failures of an unknown user are counted against, by the ID or
+// login a request named.
+func loginKey(id, login string) string {
+	if id != "" {
+		return "id:" + id
+	} else if login != "" {
+		return "login:" + daos.CanonicalLogin(login)
+	}
+	return ""
+}
+
+// sourceAddress returns the address of the client the request is made for.
+func (t *LoginThrottle) sourceAddress(ctx context.Context) string {
+	if t.TrustForwardedFor {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, forwardedFor := range md.Get("x-forwarded-for") {
+			address := strings.TrimSpace(strings.Split(forwardedFor, ",")[0])
+			if address != "" {
+				return address
+			}
+		}
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+// checkAddress returns a ResourceExhausted error if address is currently throttled.
+func (t *LoginThrottle) checkAddress(address string) error {
+	retryDelay := t.addresses.blockedFor(address)
+	if retryDelay <= 0 {
+		return nil
+	}
+
+	return retryError(codes.ResourceExhausted, "too many failed password checks, try again later", retryDelay)
+}
+
+func (t *LoginThrottle) recordAddressFailure(address string) {
+	t.addresses.record(address, t.AddressThrottle)
+}
+
+// checkAccount returns a PermissionDenied error if user is currently locked.
+func (t *LoginThrottle) checkAccount(user daos.User) error {
+	if user.LockedUntil == nil {
+		return nil
+	}
+
+	return accountLockedError(time.Until(*user.LockedUntil))
+}
+
+// checkUnknownLogin returns the error of a locked account if the login with key, which no
+// user has, failed as often as would lock an account.
+func (t *LoginThrottle) checkUnknownLogin(key string) error {
+	return accountLockedError(t.unknownLogins.blockedFor(key))
+}
+
+func (t *LoginThrottle) recordUnknownLoginFailure(key string) {
+	t.unknownLogins.record(key, t.AccountLockout)
+}
+
+// accountLockedError returns a PermissionDenied error for an account locked for retryDelay,
+// or nil if it isn't locked.
+func accountLockedError(retryDelay time.Duration) error {
+	if retryDelay <= 0 {
+		return nil
+	}
+
+	return retryError(codes.PermissionDenied, "account is temporarily locked after too many failed password checks", retryDelay)
+}
+
+func retryError(code codes.Code, message string, retryDelay time.Duration) error {
+	st := status.New(code, message)
+	withDetails, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryDelay.Round(time.Second)),
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
+
+// checkLoginAllowed returns an error if password checks for the request's source address
+// are throttled, or for user when it is locked. When user is nil, the login with key is
+// checked instead, see loginKey.
+func (u usersServerImpl) checkLoginAllowed(ctx context.Context, user *daos.User, key string) error {
+	err := u.Throttle.checkAddress(u.Throttle.sourceAddress(ctx))
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return u.Throttle.checkUnknownLogin(key)
+	}
+
+	return u.Throttle.checkAccount(*user)
+}
+
+// recordFailedLogin counts a failed password check against the request's source address
+// and user, locking the user once it reached too many failures. When user is nil, the
+// failure is counted against the login with key instead.
+func (u usersServerImpl) recordFailedLogin(ctx context.Context, user *daos.User, key string) {
+	u.Throttle.recordAddressFailure(u.Throttle.sourceAddress(ctx))
+
+	if user == nil {
+		u.Throttle.recordUnknownLoginFailure(key)
+		return
+	}
+
+	// Failures older than the longest lockout are forgotten, like the in-memory counters
+	// forget keys, so occasional typos don't add up to a lock over months
+	if user.LastFailedLoginAt != nil && time.Since(*user.LastFailedLoginAt) > u.Throttle.AccountLockout.MaxDelay {
+		err := u.UsersDAO.ResetFailedLogins(ctx, user.UserID)
+		if err != nil {
+			log.Printf("error resetting failed logins of userID %s: %v", user.UserID, err)
+		}
+	}
+
+	updatedUser, err := u.UsersDAO.RecordFailedLogin(ctx, user.UserID)
+	if err != nil {
+		log.Printf("error recording failed login of userID %s: %v", user.UserID, err)
+		return
+	}
+
+	lockout := u.Throttle.AccountLockout.delay(updatedUser.FailedLoginAttempts)
+	if lockout <= 0 {
+		return
+	}
+
+	err = u.UsersDAO.LockUser(ctx, user.UserID, time.Now().Add(lockout))
+	if err != nil {
+		log.Printf("error locking userID %s: %v", user.UserID, err)
+	}
+}
+
+// recordSuccessfulLogin clears the user's failed password checks. The source address
+// failures are kept on purpose: otherwise guessing passwords of other accounts could be
+// kept going by regularly logging into an account the attacker owns.
+func (u usersServerImpl) recordSuccessfulLogin(ctx context.Context, user daos.User) {
+	if user.FailedLoginAttempts == 0 && user.LockedUntil == nil {
+		return
+	}
+
+	err := u.UsersDAO.ResetFailedLogins(ctx, user.UserID)
+	if err != nil {
+		log.Printf("error resetting failed logins of userID %s: %v", user.UserID, err)
+	}
+}
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLockoutPolicyDelay(t *testing.T) {
+	policy := LockoutPolicy{MaxFailures: 3, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 0, want: 0},
+		{failures: 2, want: 0},
+		{failures: 3, want: time.Second},
+		{failures: 4, want: 2 * time.Second},
+		{failures: 5, want: 4 * time.Second},
+		{failures: 6, want: 5 * time.Second},
+		{failures: 100, want: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := policy.delay(tt.failures); got != tt.want {
+			t.Errorf("delay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestFailureCounterEvictsOldest(t *testing.T) {
+	policy := LockoutPolicy{MaxFailures: 1, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	counter := newFailureCounter(2)
+
+	for _, key := range []string{"first", "second", "third"} {
+		counter.record(key, policy)
+		time.Sleep(time.Millisecond)
+	}
+
+	if len(counter.keys) != 2 {
+		t.Errorf("got %d keys, want 2", len(counter.keys))
+	}
+	if counter.blockedFor("first") > 0 {
+		t.Errorf("oldest key is still blocked")
+	}
+	for _, key := range []string{"second", "third"} {
+		if counter.blockedFor(key) <= 0 {
+			t.Errorf("key %s isn't blocked", key)
+		}
+	}
+}
+
+func TestSourceAddress(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "203.0.113.7, 10.0.0.2"))
+
+	throttle := NewLoginThrottle(DefaultAccountLockout, DefaultAddressThrottle, false)
+	if got := throttle.sourceAddress(ctx); got != "10.0.0.1" {
+		t.Errorf("got address %s, want the peer address", got)
+	}
+
+	throttle.TrustForwardedFor = true
+	if got := throttle.sourceAddress(ctx); got != "203.0.113.7" {
+		t.Errorf("got address %s, want the forwarded address", got)
+	}
+}
+
+// newThrottledTestServer returns a test server whose throttle locks accounts and addresses
+// after 2 failures, and a context of requests coming from address.
+func newThrottledTestServer(t *testing.T, address string, logins ...string) (pb.UsersServer, context.Context, daos.UsersDAO, map[string]daos.User) {
+	t.Helper()
+
+	deps, users := newTestDependencies(t, logins...)
+	policy := LockoutPolicy{MaxFailures: 2, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	deps.Throttle = NewLoginThrottle(policy, policy, false)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(address), Port: 5000}})
+	return NewUsersServer(deps), ctx, deps.UsersDAO, users
+}
+
+func assertRetryInfo(t *testing.T, err error) {
+	t.Helper()
+
+	for _, detail := range status.Convert(err).Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			if retryInfo.RetryDelay.AsDuration() <= 0 {
+				t.Errorf("got retry delay %v, want a positive delay", retryInfo.RetryDelay.AsDuration())
+			}
+			return
+		}
+	}
+
+	t.Errorf("error has no RetryInfo details: %v", err)
+}
+
+func TestCheckUserPasswordLocksAccount(t *testing.T) {
+	usersServer, ctx, usersDAO, users := newThrottledTestServer(t, "10.0.0.1", "raider")
+	raider := users["raider"]
+	wrongPassword := &pb.CheckUserPasswordRequest{Id: raider.UserID, Password: "Wr0ng!Password"}
+
+	for i := 0; i < 2; i++ {
+		_, err := usersServer.CheckUserPassword(ctx, wrongPassword)
+		assertCode(t, err, codes.Unauthenticated)
+	}
+
+	stored, _ := usersDAO.GetUserByID(ctx, raider.UserID)
+	if stored.FailedLoginAttempts != 2 || stored.LockedUntil == nil {
+		t.Fatalf("got %d failed attempts, locked until %v, want 2 and locked", stored.FailedLoginAttempts, stored.LockedUntil)
+	}
+
+	// The correct password from another address is refused while the account is locked
+	otherCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 5000}})
+	_, err := usersServer.CheckUserPassword(otherCtx, &pb.CheckUserPasswordRequest{Id: raider.UserID, Password: testPassword})
+	assertCode(t, err, codes.PermissionDenied)
+	assertRetryInfo(t, err)
+
+	err = usersDAO.LockUser(ctx, raider.UserID, time.Now().Add(-time.Second))
+	if err != nil {
+		t.Fatalf("unlocking user: %v", err)
+	}
+
+	_, err = usersServer.CheckUserPassword(otherCtx, &pb.CheckUserPasswordRequest{Id: raider.UserID, Password: testPassword})
+	assertCode(t, err, codes.OK)
+
+	stored, _ = usersDAO.GetUserByID(ctx, raider.UserID)
+	if stored.FailedLoginAttempts != 0 || stored.LockedUntil != nil {
+		t.Errorf("got %d failed attempts, locked until %v, want counters reset", stored.FailedLoginAttempts, stored.LockedUntil)
+	}
+}
+
+func TestFailedLoginsDecay(t *testing.T) {
+	deps, users := newTestDependencies(t, "raider")
+	deps.Throttle = NewLoginThrottle(
+		LockoutPolicy{MaxFailures: 2, BaseDelay: time.Millisecond, MaxDelay: 20 * time.Millisecond},
+		DefaultAddressThrottle, false)
+	usersServer := NewUsersServer(deps)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	raider := users["raider"]
+	wrongPassword := &pb.CheckUserPasswordRequest{Id: raider.UserID, Password: "Wr0ng!Password"}
+
+	_, err := usersServer.CheckUserPassword(ctx, wrongPassword)
+	assertCode(t, err, codes.Unauthenticated)
+
+	// A failure after the longest lockout counts as the first again
+	time.Sleep(30 * time.Millisecond)
+	_, err = usersServer.CheckUserPassword(ctx, wrongPassword)
+	assertCode(t, err, codes.Unauthenticated)
+
+	stored, _ := deps.UsersDAO.GetUserByID(ctx, raider.UserID)
+	if stored.FailedLoginAttempts != 1 || stored.LockedUntil != nil {
+		t.Errorf("got %d failed attempts, locked until %v, want 1 and unlocked", stored.FailedLoginAttempts, stored.LockedUntil)
+	}
+}
+
+func TestLoginThrottlesAddress(t *testing.T) {
+	usersServer, ctx, _, _ := newThrottledTestServer(t, "10.0.0.1", "raider", "healer")
+
+	// Guessing logins of unknown users counts against the address as well
+	for _, login := range []string{"unknown", "raider"} {
+		_, err := usersServer.Login(ctx, &pb.LoginRequest{Login: login, Password: "Wr0ng!Password"})
+		assertCode(t, err, codes.Unauthenticated)
+	}
+
+	_, err := usersServer.Login(ctx, &pb.LoginRequest{Login: "healer", Password: testPassword})
+	assertCode(t, err, codes.ResourceExhausted)
+	assertRetryInfo(t, err)
+
+	otherCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 5000}})
+	_, err = usersServer.Login(otherCtx, &pb.LoginRequest{Login: "healer", Password: testPassword})
+	assertCode(t, err, codes.OK)
+}
+
+func TestUnknownLoginsLockLikeAccounts(t *testing.T) {
+	usersServer, _, _, _ := newThrottledTestServer(t, "10.0.0.1", "raider")
+	otherCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.9"), Port: 5000}})
+
+	var lockedErrs []error
+	for i, login := range []string{"raider", "unknown"} {
+		// Each login is guessed from its own address so only the login gets locked
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, byte(2+i)), Port: 5000}})
+		for j := 0; j < 2; j++ {
+			_, err := usersServer.CheckUserPassword(ctx, &pb.CheckUserPasswordRequest{Login: login, Password: "Wr0ng!Password"})
+			assertCode(t, err, codes.Unauthenticated)
+		}
+
+		_, err := usersServer.CheckUserPassword(otherCtx, &pb.CheckUserPasswordRequest{Login: strings.ToUpper(login), Password: testPassword})
+		assertCode(t, err, codes.PermissionDenied)
+		assertRetryInfo(t, err)
+		lockedErrs = append(lockedErrs, err)
+	}
+
+	if status.Convert(lockedErrs[0]).Message() != status.Convert(lockedErrs[1]).Message() {
+		t.Errorf("got %v for a locked account and %v for a locked unknown login, want the same error", lockedErrs[0], lockedErrs[1])
+	}
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"time"
+)
+
+// TokenTTLs are how long the single-use tokens issued by the server stay valid.
+type TokenTTLs struct {
+	EmailVerification time.Duration
+	PasswordReset     time.Duration
+	// SecondFactor is how long users have to enter their second factor after their password.
+	SecondFactor time.Duration
+}
+
+var DefaultTokenTTLs = TokenTTLs{
+	EmailVerification: 24 * time.Hour,
+	PasswordReset:     time.Hour,
+	SecondFactor:      5 * time.Minute,
+}
+
+// NewTimeoutInterceptor returns an interceptor giving every call at most timeout to
+// complete, or less when the client set an earlier deadline.
+func NewTimeoutInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/raidcomp/users-service/auth"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"time"
+)
+
+// totpIssuer is the name authenticator apps show the account under.
+const totpIssuer = "Raidcomp"
+
+// newSecondFactorToken returns a token that completes a successful password check of user
+// once its second factor is verified, issuing a session if issueSession is set.
+func (u usersServerImpl) newSecondFactorToken(ctx context.Context, user daos.User, issueSession bool) (string, error) {
+	token, _, err := u.createToken(ctx, daos.Token{
+		Purpose:      daos.TokenPurposeSecondFactor,
+		UserID:       user.UserID,
+		IssueSession: issueSession,
+	}, u.TokenTTLs.SecondFactor)
+	return token, err
+}
+
+// openTOTPSecret decrypts the user's TOTP secret.
+func (u usersServerImpl) openTOTPSecret(user daos.User) ([]byte, error) {
+	return u.Secrets.Open(user.TOTPSecret, []byte(user.UserID))
+}
+
+// checkOwnSecondFactor verifies the TOTP code or recovery code of a request when users
+// call for themselves, so a stolen access token alone can't turn off or replace their
+// second factor. Admins and services calling for other users don't need one.
+func (u usersServerImpl) checkOwnSecondFactor(ctx context.Context, user daos.User, code, recoveryCode string) error {
+	identity, ok := IdentityFromContext(ctx)
+	if !ok || identity.UserID != user.UserID || !user.TOTPEnabled {
+		return nil
+	}
+
+	err := u.checkLoginAllowed(ctx, &user, "")
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case code != "":
+		secret, err := u.openTOTPSecret(user)
+		if err != nil {
+			log.Printf("error decrypting TOTP secret of userID %s: %v", user.UserID, err)
+			return status.Errorf(codes.Internal, "error decrypting TOTP secret")
+		}
+
+		step, ok := auth.ValidateTOTP(secret, code, time.Now())
+		if ok {
+			ok, err = u.UsersDAO.RecordTOTPStep(ctx, user.UserID, step)
+			if err != nil {
+				return daoError(err, "error recording TOTP code")
+			}
+		}
+		if ok {
+			return nil
+		}
+	case recoveryCode != "":
+		_, err := u.UsersDAO.ConsumeRecoveryCode(ctx, user.UserID, auth.HashRecoveryCode(recoveryCode))
+		if err == nil {
+			return nil
+		} else if !errors.Is(err, daos.ErrNotFound) {
+			return daoError(err, "error consuming recovery code")
+		}
+	default:
+		return status.Errorf(codes.PermissionDenied, "a TOTP code or recovery code is required")
+	}
+
+	u.recordFailedLogin(ctx, &user, "")
+	return status.Errorf(codes.PermissionDenied, "TOTP code or recovery code is invalid")
+}
+
+func (u usersServerImpl) BeginTOTPEnrollment(ctx context.Context, req *pb.BeginTOTPEnrollmentRequest) (*pb.BeginTOTPEnrollmentResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	user, err := u.UsersDAO.GetUserByID(ctx, req.Id)
+	if err != nil {
+		return nil, daoError(err, "error getting user")
+	}
+
+	if user.TOTPEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "TOTP is already enabled for userID %s", req.Id)
+	}
+
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error creating TOTP secret")
+	}
+
+	encryptedSecret, err := u.Secrets.Seal(secret, []byte(user.UserID))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error encrypting TOTP secret")
+	}
+
+	_, err = u.UsersDAO.SetPendingTOTPSecret(ctx, user.UserID, encryptedSecret)
+	if errors.Is(err, daos.ErrTOTPEnabled) {
+		return nil, status.Errorf(codes.FailedPrecondition, "TOTP is already enabled for userID %s", req.Id)
+	} else if err != nil {
+		return nil, daoError(err, "error storing TOTP secret")
+	}
+
+	return &pb.BeginTOTPEnrollmentResponse{
+		Secret: auth.EncodeTOTPSecret(secret),
+		Uri:    auth.TOTPURI(totpIssuer, user.Login, secret),
+	}, nil
+}
+
+func (u usersServerImpl) ConfirmTOTPEnrollment(ctx context.Context, req *pb.ConfirmTOTPEnrollmentRequest) (*pb.ConfirmTOTPEnrollmentResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	user, err := u.UsersDAO.GetUserByID(ctx, req.Id)
+	if err != nil {
+		return nil, daoError(err, "error getting user")
+	}
+
+	if user.TOTPEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "TOTP is already enabled for userID %s", req.Id)
+	} else if user.TOTPSecret == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "TOTP enrollment of userID %s was not started", req.Id)
+	}
+
+	secret, err := u.openTOTPSecret(*user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error decrypting TOTP secret")
+	}
+
+	step, ok := auth.ValidateTOTP(secret, req.Code, time.Now())
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "TOTP code is invalid")
+	}
+
+	updatedUser, err := u.UsersDAO.EnableTOTP(ctx, user.UserID, user.TOTPSecret, step)
+	if errors.Is(err, daos.ErrConcurrentModification) {
+		return nil, status.Errorf(codes.Aborted, "TOTP enrollment was restarted concurrently, try again")
+	} else if err != nil {
+		return nil, daoError(err, "error enabling TOTP")
+	}
+
+	return &pb.ConfirmTOTPEnrollmentResponse{
+		User: toPBUser(*updatedUser),
+	}, nil
+}
+
+func (u usersServerImpl) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	user, err := u.UsersDAO.GetUserByID(ctx, req.Id)
+	if err != nil {
+		return nil, daoError(err, "error getting user")
+	}
+
+	err = u.checkOwnSecondFactor(ctx, *user, req.Code, req.RecoveryCode)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedUser, err := u.UsersDAO.DisableTOTP(ctx, user.UserID)
+	if err != nil {
+		return nil, daoError(err, "error disabling TOTP")
+	}
+
+	return &pb.DisableTOTPResponse{
+		User: toPBUser(*updatedUser),
+	}, nil
+}
+
+// redeemSecondFactorToken consumes a second factor token and returns it along with its
+// user, or a status error if the token is invalid or password checks are throttled.
+// Tokens are consumed by every attempt, so each guessed second factor costs a password check.
+func (u usersServerImpl) redeemSecondFactorToken(ctx context.Context, secondFactorToken string) (*daos.Token, *daos.User, error) {
+	token, err := u.TokensDAO.ConsumeToken(ctx, daos.TokenPurposeSecondFactor, auth.HashToken(secondFactorToken))
+	if errors.Is(err, daos.ErrNotFound) {
+		return nil, nil, status.Errorf(codes.Unauthenticated, "second factor token is invalid or expired")
+	} else if err != nil {
+		return nil, nil, daoError(err, "error consuming second factor token")
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		return nil, nil, status.Errorf(codes.Unauthenticated, "second factor token is invalid or expired")
+	}
+
+	// Tokens of deleted users are still throttled like any other invalid token
+	user, err := u.UsersDAO.GetUserByID(ctx, token.UserID)
+	if err != nil && !errors.Is(err, daos.ErrNotFound) {
+		return nil, nil, daoError(err, "error getting user")
+	}
+
+	err = u.checkLoginAllowed(ctx, user, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if user == nil || !user.TOTPEnabled {
+		return nil, nil, status.Errorf(codes.Unauthenticated, "second factor token is invalid or expired")
+	}
+
+	return token, user, nil
+}
+
+// completeSecondFactor returns the session for a verified second factor token when it
+// was handed out by Login, or nil otherwise.
+func (u usersServerImpl) completeSecondFactor(ctx context.Context, token daos.Token, user daos.User) (*pb.Session, error) {
+	if !token.IssueSession {
+		return nil, nil
+	}
+
+	return u.createSession(ctx, user)
+}
+
+func (u usersServerImpl) VerifyTOTP(ctx context.Context, req *pb.VerifyTOTPRequest) (*pb.VerifyTOTPResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	token, user, err := u.redeemSecondFactorToken(ctx, req.SecondFactorToken)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := u.openTOTPSecret(*user)
+	if err != nil {
+		log.Printf("error decrypting TOTP secret of userID %s: %v", user.UserID, err)
+		return nil, status.Errorf(codes.Internal, "error decrypting TOTP secret")
+	}
+
+	step, ok := auth.ValidateTOTP(secret, req.Code, time.Now())
+	if ok {
+		// Codes stay valid for a while, they must not be accepted twice
+		ok, err = u.UsersDAO.RecordTOTPStep(ctx, user.UserID, step)
+		if err != nil {
+			return nil, daoError(err, "error recording TOTP code")
+		}
+	}
+
+	if !ok {
+		u.recordFailedLogin(ctx, user, "")
+		return nil, status.Errorf(codes.Unauthenticated, "TOTP code is invalid")
+	}
+
+	u.recordSuccessfulLogin(ctx, *user)
+
+	session, err := u.completeSecondFactor(ctx, *token, *user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.VerifyTOTPResponse{
+		User:    toPBUser(*user),
+		Session: session,
+	}, nil
+}
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"encoding/base32"
+	"github.com/raidcomp/users-service/auth"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"net"
+	"testing"
+	"time"
+)
+
+// enrollTOTP enrolls the user in TOTP with a code of the previous time step, leaving the
+// current and next steps' codes unused, and returns the secret.
+func enrollTOTP(t *testing.T, usersServer pb.UsersServer, userID string) []byte {
+	t.Helper()
+	ctx := context.Background()
+
+	begin, err := usersServer.BeginTOTPEnrollment(ctx, &pb.BeginTOTPEnrollmentRequest{Id: userID})
+	assertCode(t, err, codes.OK)
+
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(begin.Secret)
+	if err != nil {
+		t.Fatalf("decoding secret: %v", err)
+	}
+
+	code := auth.TOTPCode(secret, auth.TOTPStep(time.Now())-1)
+	confirm, err := usersServer.ConfirmTOTPEnrollment(ctx, &pb.ConfirmTOTPEnrollmentRequest{Id: userID, Code: code})
+	assertCode(t, err, codes.OK)
+	if !confirm.User.TotpEnabled {
+		t.Fatalf("TOTP is not enabled after confirming enrollment")
+	}
+
+	return secret
+}
+
+// selfContext returns the context of a call the Authorizer allowed for the user itself.
+func selfContext(userID string) context.Context {
+	return context.WithValue(context.Background(), identityKey{}, Identity{UserID: userID})
+}
+
+func TestTOTPEnrollment(t *testing.T) {
+	ctx := context.Background()
+	usersServer, usersDAO, users := newTestServer(t, "raider")
+	raider := users["raider"]
+
+	_, err := usersServer.ConfirmTOTPEnrollment(ctx, &pb.ConfirmTOTPEnrollmentRequest{Id: raider.UserID, Code: "123456"})
+	assertCode(t, err, codes.FailedPrecondition)
+
+	begin, err := usersServer.BeginTOTPEnrollment(ctx, &pb.BeginTOTPEnrollmentRequest{Id: raider.UserID})
+	assertCode(t, err, codes.OK)
+	if begin.Secret == "" || begin.Uri == "" {
+		t.Fatalf("got secret %q and uri %q", begin.Secret, begin.Uri)
+	}
+
+	stored, _ := usersDAO.GetUserByID(ctx, raider.UserID)
+	if stored.TOTPSecret == "" || stored.TOTPSecret == begin.Secret {
+		t.Errorf("got stored secret %q, want the encrypted secret", stored.TOTPSecret)
+	}
+
+	secret, _ := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(begin.Secret)
+	wrongCode := auth.TOTPCode(secret, auth.TOTPStep(time.Now())+10)
+	_, err = usersServer.ConfirmTOTPEnrollment(ctx, &pb.ConfirmTOTPEnrollmentRequest{Id: raider.UserID, Code: wrongCode})
+	assertCode(t, err, codes.InvalidArgument)
+
+	code := auth.TOTPCode(secret, auth.TOTPStep(time.Now()))
+	_, err = usersServer.ConfirmTOTPEnrollment(ctx, &pb.ConfirmTOTPEnrollmentRequest{Id: raider.UserID, Code: code})
+	assertCode(t, err, codes.OK)
+
+	_, err = usersServer.BeginTOTPEnrollment(ctx, &pb.BeginTOTPEnrollmentRequest{Id: raider.UserID})
+	assertCode(t, err, codes.FailedPrecondition)
+
+	disabled, err := usersServer.DisableTOTP(ctx, &pb.DisableTOTPRequest{Id: raider.UserID})
+	assertCode(t, err, codes.OK)
+	if disabled.User.TotpEnabled {
+		t.Errorf("TOTP is still enabled")
+	}
+
+	check, err := usersServer.CheckUserPassword(ctx, &pb.CheckUserPasswordRequest{Id: raider.UserID, Password: testPassword})
+	assertCode(t, err, codes.OK)
+	if check.SecondFactorRequired {
+		t.Errorf("second factor required after disabling TOTP")
+	}
+}
+
+func TestCheckUserPasswordWithTOTP(t *testing.T) {
+	ctx := context.Background()
+	usersServer, _, users := newTestServer(t, "raider")
+	raider := users["raider"]
+	secret := enrollTOTP(t, usersServer, raider.UserID)
+	step := auth.TOTPStep(time.Now())
+
+	check, err := usersServer.CheckUserPassword(ctx, &pb.CheckUserPasswordRequest{Id: raider.UserID, Password: testPassword})
+	assertCode(t, err, codes.OK)
+	if !check.SecondFactorRequired || check.SecondFactorToken == "" {
+		t.Fatalf("got second factor required %v with token %q", check.SecondFactorRequired, check.SecondFactorToken)
+	}
+
+	// Codes already used are refused, and refused codes consume the token
+	_, err = usersServer.VerifyTOTP(ctx, &pb.VerifyTOTPRequest{SecondFactorToken: check.SecondFactorToken, Code: auth.TOTPCode(secret, step-1)})
+	assertCode(t, err, codes.Unauthenticated)
+
+	_, err = usersServer.VerifyTOTP(ctx, &pb.VerifyTOTPRequest{SecondFactorToken: check.SecondFactorToken, Code: auth.TOTPCode(secret, step)})
+	assertCode(t, err, codes.Unauthenticated)
+
+	check, err = usersServer.CheckUserPassword(ctx, &pb.CheckUserPasswordRequest{Id: raider.UserID, Password: testPassword})
+	assertCode(t, err, codes.OK)
+
+	verified, err := usersServer.VerifyTOTP(ctx, &pb.VerifyTOTPRequest{SecondFactorToken: check.SecondFactorToken, Code: auth.TOTPCode(secret, step)})
+	assertCode(t, err, codes.OK)
+	if verified.User.Id != raider.UserID {
+		t.Errorf("got user %s, want %s", verified.User.Id, raider.UserID)
+	}
+	if verified.Session != nil {
+		t.Errorf("got a session for a password check")
+	}
+}
+
+func TestLoginWithTOTP(t *testing.T) {
+	ctx := context.Background()
+	usersServer, _, users := newTestServer(t, "raider")
+	raider := users["raider"]
+	secret := enrollTOTP(t, usersServer, raider.UserID)
+
+	login, err := usersServer.Login(ctx, &pb.LoginRequest{Login: "raider", Password: testPassword})
+	assertCode(t, err, codes.OK)
+	if !login.SecondFactorRequired || login.Session != nil {
+		t.Fatalf("got second factor required %v with session %v", login.SecondFactorRequired, login.Session)
+	}
+
+	verified, err := usersServer.VerifyTOTP(ctx, &pb.VerifyTOTPRequest{SecondFactorToken: login.SecondFactorToken, Code: auth.TOTPCode(secret, auth.TOTPStep(time.Now()))})
+	assertCode(t, err, codes.OK)
+	if verified.Session == nil {
+		t.Fatalf("got no session completing a login")
+	}
+
+	_, err = usersServer.RefreshSession(ctx, &pb.RefreshSessionRequest{RefreshToken: verified.Session.RefreshToken})
+	assertCode(t, err, codes.OK)
+}
+
+func TestDisableOwnTOTP(t *testing.T) {
+	usersServer, _, users := newTestServer(t, "raider")
+	raider := users["raider"]
+	secret := enrollTOTP(t, usersServer, raider.UserID)
+	ctx := selfContext(raider.UserID)
+	step := auth.TOTPStep(time.Now())
+
+	_, err := usersServer.DisableTOTP(ctx, &pb.DisableTOTPRequest{Id: raider.UserID})
+	assertCode(t, err, codes.PermissionDenied)
+
+	_, err = usersServer.DisableTOTP(ctx, &pb.DisableTOTPRequest{Id: raider.UserID, Code: auth.TOTPCode(secret, step+10)})
+	assertCode(t, err, codes.PermissionDenied)
+
+	// The code enrollment was confirmed with was used already
+	_, err = usersServer.DisableTOTP(ctx, &pb.DisableTOTPRequest{Id: raider.UserID, Code: auth.TOTPCode(secret, step-1)})
+	assertCode(t, err, codes.PermissionDenied)
+
+	_, err = usersServer.DisableTOTP(ctx, &pb.DisableTOTPRequest{Id: raider.UserID, RecoveryCode: "not-a-recovery-code"})
+	assertCode(t, err, codes.PermissionDenied)
+
+	disabled, err := usersServer.DisableTOTP(ctx, &pb.DisableTOTPRequest{Id: raider.UserID, Code: auth.TOTPCode(secret, step)})
+	assertCode(t, err, codes.OK)
+	if disabled.User.TotpEnabled {
+		t.Errorf("TOTP is still enabled")
+	}
+}
+
+func TestWrongTOTPCodesLockAccount(t *testing.T) {
+	usersServer, _, usersDAO, users := newThrottledTestServer(t, "10.0.0.1", "raider")
+	raider := users["raider"]
+	secret := enrollTOTP(t, usersServer, raider.UserID)
+	wrongCode := auth.TOTPCode(secret, auth.TOTPStep(time.Now())+10)
+
+	// Correct passwords don't clear the failures of wrong codes in between
+	for i := 0; i < 2; i++ {
+		// Each attempt comes from its own address so only the account gets locked
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, byte(2+i)), Port: 5000}})
+		login, err := usersServer.Login(ctx, &pb.LoginRequest{Login: "raider", Password: testPassword})
+		assertCode(t, err, codes.OK)
+
+		_, err = usersServer.VerifyTOTP(ctx, &pb.VerifyTOTPRequest{SecondFactorToken: login.SecondFactorToken, Code: wrongCode})
+		assertCode(t, err, codes.Unauthenticated)
+	}
+
+	otherCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.9"), Port: 5000}})
+	_, err := usersServer.Login(otherCtx, &pb.LoginRequest{Login: "raider", Password: testPassword})
+	assertCode(t, err, codes.PermissionDenied)
+
+	err = usersDAO.LockUser(otherCtx, raider.UserID, time.Now().Add(-time.Second))
+	if err != nil {
+		t.Fatalf("unlocking user: %v", err)
+	}
+
+	login, err := usersServer.Login(otherCtx, &pb.LoginRequest{Login: "raider", Password: testPassword})
+	assertCode(t, err, codes.OK)
+	stored, _ := usersDAO.GetUserByID(otherCtx, raider.UserID)
+	if stored.FailedLoginAttempts != 2 {
+		t.Errorf("got %d failed attempts after a correct password, want 2", stored.FailedLoginAttempts)
+	}
+
+	_, err = usersServer.VerifyTOTP(otherCtx, &pb.VerifyTOTPRequest{SecondFactorToken: login.SecondFactorToken, Code: auth.TOTPCode(secret, auth.TOTPStep(time.Now()))})
+	assertCode(t, err, codes.OK)
+	stored, _ = usersDAO.GetUserByID(otherCtx, raider.UserID)
+	if stored.FailedLoginAttempts != 0 || stored.LockedUntil != nil {
+		t.Errorf("got %d failed attempts, locked until %v, want counters reset", stored.FailedLoginAttempts, stored.LockedUntil)
+	}
+}
@@ -0,0 +1,270 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/raidcomp/users-service/auth"
+	"github.com/raidcomp/users-service/daos"
+	pb "github.com/raidcomp/users-service/proto"
+	"github.com/raidcomp/users-service/webauthn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"time"
+)
+
+// webAuthnChallengeTTL matches the timeout browsers are given in the options.
+const webAuthnChallengeTTL = 5 * time.Minute
+
+// checkWebAuthnEnabled returns a status error if no relying party is configured.
+func (u usersServerImpl) checkWebAuthnEnabled() error {
+	if u.WebAuthn == nil {
+		return status.Errorf(codes.Unimplemented, "WebAuthn is not enabled")
+	}
+	return nil
+}
+
+// webAuthnCredentialIDs returns the decoded IDs of the user's credentials.
+func webAuthnCredentialIDs(user daos.User) [][]byte {
+	ids := make([][]byte, 0, len(user.WebAuthnCredentialIDs))
+	for _, encodedID := range user.WebAuthnCredentialIDs {
+		id, err := webauthn.DecodeID(encodedID)
+		if err != nil {
+			log.Printf("invalid WebAuthn credential ID %s of userID %s: %v", encodedID, user.UserID, err)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// consumeWebAuthnChallenge consumes the challenge client data was signed for, returning
+// the challenge along with its token, or nil if it is invalid or expired.
+func (u usersServerImpl) consumeWebAuthnChallenge(ctx context.Context, purpose daos.TokenPurpose, clientDataJSON []byte) (string, *daos.Token, error) {
+	clientData, err := webauthn.ParseClientData(clientDataJSON)
+	if err != nil {
+		return "", nil, nil
+	}
+
+	token, err := u.TokensDAO.ConsumeToken(ctx, purpose, auth.HashToken(clientData.Challenge))
+	if errors.Is(err, daos.ErrNotFound) {
+		return "", nil, nil
+	} else if err != nil {
+		return "", nil, daoError(err, "error consuming WebAuthn challenge")
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		return "", nil, nil
+	}
+
+	return clientData.Challenge, token, nil
+}
+
+func (u usersServerImpl) BeginWebAuthnRegistration(ctx context.Context, req *pb.BeginWebAuthnRegistrationRequest) (*pb.BeginWebAuthnRegistrationResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	err = u.checkWebAuthnEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.UsersDAO.GetUserByID(ctx, req.Id)
+	if err != nil {
+		return nil, daoError(err, "error getting user")
+	}
+
+	if len(user.WebAuthnCredentialIDs) >= daos.MaxWebAuthnCredentials {
+		return nil, status.Errorf(codes.FailedPrecondition, "userID %s already has %d WebAuthn credentials", req.Id, daos.MaxWebAuthnCredentials)
+	}
+
+	challenge, _, err := u.createToken(ctx, daos.Token{
+		Purpose: daos.TokenPurposeWebAuthnRegistration,
+		UserID:  user.UserID,
+	}, webAuthnChallengeTTL)
+	if err != nil {
+		return nil, daoError(err, "error creating WebAuthn challenge")
+	}
+
+	// Registering a credential again would only replace it on the authenticator
+	options, err := u.WebAuthn.CreationOptions(challenge, []byte(user.UserID), user.Login, webAuthnCredentialIDs(*user))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error creating WebAuthn options")
+	}
+
+	return &pb.BeginWebAuthnRegistrationResponse{
+		Options: string(options),
+	}, nil
+}
+
+func (u usersServerImpl) FinishWebAuthnRegistration(ctx context.Context, req *pb.FinishWebAuthnRegistrationRequest) (*pb.FinishWebAuthnRegistrationResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	err = u.checkWebAuthnEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, token, err := u.consumeWebAuthnChallenge(ctx, daos.TokenPurposeWebAuthnRegistration, req.ClientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if token == nil || token.UserID != req.Id {
+		return nil, status.Errorf(codes.InvalidArgument, "WebAuthn challenge is invalid or expired")
+	}
+
+	credential, err := u.WebAuthn.VerifyRegistration(challenge, req.ClientDataJSON, req.AttestationObject)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "WebAuthn registration is invalid: %v", err)
+	}
+
+	user, err := u.UsersDAO.AddWebAuthnCredential(ctx, daos.WebAuthnCredential{
+		CredentialID: webauthn.EncodeID(credential.ID),
+		UserID:       req.Id,
+		Name:         req.Name,
+		PublicKey:    credential.PublicKey,
+		SignCount:    credential.SignCount,
+		CreatedAt:    time.Now(),
+	})
+	if errors.Is(err, daos.ErrWebAuthnCredentialTaken) {
+		return nil, status.Errorf(codes.AlreadyExists, "WebAuthn credential is already registered")
+	} else if err != nil {
+		return nil, daoError(err, "error storing WebAuthn credential")
+	}
+
+	return &pb.FinishWebAuthnRegistrationResponse{
+		User: toPBUser(*user),
+	}, nil
+}
+
+func (u usersServerImpl) BeginWebAuthnAssertion(ctx context.Context, req *pb.BeginWebAuthnAssertionRequest) (*pb.BeginWebAuthnAssertionResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	err = u.checkWebAuthnEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a user, any discoverable credential of the site may answer the challenge.
+	// Unknown users and users without credentials get the same challenge, so this public
+	// method doesn't tell which logins exist.
+	var token daos.Token
+	var allowCredentialIDs [][]byte
+	if req.Id != "" || req.Login != "" {
+		user, err := u.findUser(ctx, req.Id, req.Login)
+		if err != nil && !errors.Is(err, daos.ErrNotFound) {
+			return nil, daoError(err, "error getting user")
+		}
+
+		if user != nil && len(user.WebAuthnCredentialIDs) > 0 {
+			token.UserID = user.UserID
+			allowCredentialIDs = webAuthnCredentialIDs(*user)
+		}
+	}
+
+	token.Purpose = daos.TokenPurposeWebAuthnAssertion
+	challenge, _, err := u.createToken(ctx, token, webAuthnChallengeTTL)
+	if err != nil {
+		return nil, daoError(err, "error creating WebAuthn challenge")
+	}
+
+	options, err := u.WebAuthn.RequestOptions(challenge, allowCredentialIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error creating WebAuthn options")
+	}
+
+	return &pb.BeginWebAuthnAssertionResponse{
+		Options: string(options),
+	}, nil
+}
+
+func (u usersServerImpl) FinishWebAuthnAssertion(ctx context.Context, req *pb.FinishWebAuthnAssertionRequest) (*pb.FinishWebAuthnAssertionResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	err = u.checkWebAuthnEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, token, err := u.consumeWebAuthnChallenge(ctx, daos.TokenPurposeWebAuthnAssertion, req.ClientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if token == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "WebAuthn challenge is invalid or expired")
+	}
+
+	credential, err := u.UsersDAO.GetWebAuthnCredential(ctx, webauthn.EncodeID(req.CredentialId))
+	if errors.Is(err, daos.ErrNotFound) {
+		return nil, status.Errorf(codes.Unauthenticated, "WebAuthn credential is not registered")
+	} else if err != nil {
+		return nil, daoError(err, "error getting WebAuthn credential")
+	}
+
+	// The challenge may have been issued for a user, and discoverable credentials name theirs
+	if (token.UserID != "" && token.UserID != credential.UserID) ||
+		(len(req.UserHandle) > 0 && string(req.UserHandle) != credential.UserID) {
+		return nil, status.Errorf(codes.Unauthenticated, "WebAuthn credential is not registered")
+	}
+
+	user, err := u.UsersDAO.GetUserByID(ctx, credential.UserID)
+	if err != nil && !errors.Is(err, daos.ErrNotFound) {
+		return nil, daoError(err, "error getting user")
+	}
+
+	err = u.checkLoginAllowed(ctx, user, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "WebAuthn credential is not registered")
+	}
+
+	signCount, err := u.WebAuthn.VerifyAssertion(challenge, webauthn.Credential{
+		PublicKey: credential.PublicKey,
+		SignCount: credential.SignCount,
+	}, req.ClientDataJSON, req.AuthenticatorData, req.Signature)
+	if errors.Is(err, webauthn.ErrSignCountRegressed) {
+		log.Printf("WebAuthn credential %s of userID %s may be cloned: %v", credential.CredentialID, user.UserID, err)
+	}
+	if err != nil {
+		u.recordFailedLogin(ctx, user, "")
+		return nil, status.Errorf(codes.Unauthenticated, "WebAuthn assertion is invalid")
+	}
+
+	ok, err := u.UsersDAO.UpdateWebAuthnSignCount(ctx, credential.CredentialID, credential.SignCount, signCount)
+	if err != nil {
+		return nil, daoError(err, "error updating WebAuthn credential")
+	}
+
+	if !ok {
+		log.Printf("WebAuthn credential %s of userID %s was used concurrently", credential.CredentialID, user.UserID)
+		return nil, status.Errorf(codes.Unauthenticated, "WebAuthn assertion is invalid")
+	}
+
+	u.recordSuccessfulLogin(ctx, *user)
+
+	session, err := u.createSession(ctx, *user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.FinishWebAuthnAssertionResponse{
+		User:    toPBUser(*user),
+		Session: session,
+	}, nil
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/fxamacker/cbor/v2"
+	pb "github.com/raidcomp/users-service/proto"
+	"google.golang.org/grpc/codes"
+	"testing"
+)
+
+// testPasskey is a software authenticator holding a single ES256 credential.
+type testPasskey struct {
+	credentialID []byte
+	key          *ecdsa.PrivateKey
+	signCount    uint32
+}
+
+func newTestPasskey(t *testing.T) *testPasskey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	return &testPasskey{credentialID: []byte("passkey-" + t.Name()), key: key}
+}
+
+// challenge returns the challenge of JSON options returned by a Begin RPC.
+func challenge(t *testing.T, options string) string {
+	var decoded struct {
+		Challenge string `json:"challenge"`
+	}
+	err := json.Unmarshal([]byte(options), &decoded)
+	if err != nil || decoded.Challenge == "" {
+		t.Fatalf("got options %s (err: %v)", options, err)
+	}
+	return decoded.Challenge
+}
+
+func (p *testPasskey) clientData(t *testing.T, ceremonyType, challenge string) []byte {
+	data, err := json.Marshal(map[string]string{"type": ceremonyType, "challenge": challenge, "origin": "https://raidcomp.io"})
+	if err != nil {
+		t.Fatalf("encoding client data: %v", err)
+	}
+	return data
+}
+
+func (p *testPasskey) authData(attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(testRelyingParty.ID))
+	flags := byte(0x01 | 0x04)
+	if attested {
+		flags |= 0x40
+	}
+
+	data := append(rpIDHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, p.signCount)
+	if attested {
+		publicKey, _ := cbor.Marshal(map[int]interface{}{
+			1: 2, 3: -7, -1: 1,
+			-2: p.key.X.FillBytes(make([]byte, 32)),
+			-3: p.key.Y.FillBytes(make([]byte, 32)),
+		})
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(p.credentialID)))
+		data = append(data, p.credentialID...)
+		data = append(data, publicKey...)
+	}
+	return data
+}
+
+func (p *testPasskey) register(t *testing.T, userID, options string) *pb.FinishWebAuthnRegistrationRequest {
+	attestation, err := cbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": p.authData(true),
+	})
+	if err != nil {
+		t.Fatalf("encoding attestation: %v", err)
+	}
+
+	return &pb.FinishWebAuthnRegistrationRequest{
+		Id:                userID,
+		ClientDataJSON:    p.clientData(t, "webauthn.create", challenge(t, options)),
+		AttestationObject: attestation,
+		Name:              "test passkey",
+	}
+}
+
+func (p *testPasskey) assert(t *testing.T, options string) *pb.FinishWebAuthnAssertionRequest {
+	p.signCount++
+	authData := p.authData(false)
+	clientData := p.clientData(t, "webauthn.get", challenge(t, options))
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, p.key, digest[:])
+	if err != nil {
+		t.Fatalf("signing: %v", err)
+	}
+
+	return &pb.FinishWebAuthnAssertionRequest{
+		CredentialId:      p.credentialID,
+		ClientDataJSON:    clientData,
+		AuthenticatorData: authData,
+		Signature:         signature,
+	}
+}
+
+// registerPasskey registers a new passkey for the user.
+func registerPasskey(t *testing.T, usersServer pb.UsersServer, userID string) *testPasskey {
+	t.Helper()
+	ctx := context.Background()
+
+	begin, err := usersServer.BeginWebAuthnRegistration(ctx, &pb.BeginWebAuthnRegistrationRequest{Id: userID})
+	assertCode(t, err, codes.OK)
+
+	passkey := newTestPasskey(t)
+	finish, err := usersServer.FinishWebAuthnRegistration(ctx, passkey.register(t, userID, begin.Options))
+	assertCode(t, err, codes.OK)
+	if finish.User.WebAuthnCredentialCount != 1 {
+		t.Fatalf("got %d credentials after registering, want 1", finish.User.WebAuthnCredentialCount)
+	}
+
+	return passkey
+}
+
+func TestWebAuthnRegistration(t *testing.T) {
+	ctx := context.Background()
+	usersServer, _, users := newTestServer(t, "raider", "healer")
+	raider := users["raider"]
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := usersServer.BeginWebAuthnRegistration(ctx, &pb.BeginWebAuthnRegistrationRequest{Id: "unknown"})
+		assertCode(t, err, codes.NotFound)
+	})
+
+	t.Run("challenge of another user", func(t *testing.T) {
+		begin, err := usersServer.BeginWebAuthnRegistration(ctx, &pb.BeginWebAuthnRegistrationRequest{Id: users["healer"].UserID})
+		assertCode(t, err, codes.OK)
+
+		_, err = usersServer.FinishWebAuthnRegistration(ctx, newTestPasskey(t).register(t, raider.UserID, begin.Options))
+		assertCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("registered twice", func(t *testing.T) {
+		passkey := registerPasskey(t, usersServer, raider.UserID)
+
+		begin, err := usersServer.BeginWebAuthnRegistration(ctx, &pb.BeginWebAuthnRegistrationRequest{Id: raider.UserID})
+		assertCode(t, err, codes.OK)
+
+		_, err = usersServer.FinishWebAuthnRegistration(ctx, passkey.register(t, raider.UserID, begin.Options))
+		assertCode(t, err, codes.AlreadyExists)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		deps, users := newTestDependencies(t, "raider")
+		deps.WebAuthn = nil
+
+		_, err := NewUsersServer(deps).BeginWebAuthnRegistration(ctx, &pb.BeginWebAuthnRegistrationRequest{Id: users["raider"].UserID})
+		assertCode(t, err, codes.Unimplemented)
+	})
+}
+
+func TestWebAuthnAssertion(t *testing.T) {
+	ctx := context.Background()
+	usersServer, _, users := newTestServer(t, "raider", "healer")
+	raider := users["raider"]
+	passkey := registerPasskey(t, usersServer, raider.UserID)
+
+	t.Run("by login", func(t *testing.T) {
+		begin, err := usersServer.BeginWebAuthnAssertion(ctx, &pb.BeginWebAuthnAssertionRequest{Login: "raider"})
+		assertCode(t, err, codes.OK)
+
+		finish, err := usersServer.FinishWebAuthnAssertion(ctx, passkey.assert(t, begin.Options))
+		assertCode(t, err, codes.OK)
+		if finish.User.Id != raider.UserID || finish.Session.GetAccessToken() == "" {
+			t.Errorf("got user %v and session %v", finish.User, finish.Session)
+		}
+	})
+
+	t.Run("discoverable credential", func(t *testing.T) {
+		begin, err := usersServer.BeginWebAuthnAssertion(ctx, &pb.BeginWebAuthnAssertionRequest{})
+		assertCode(t, err, codes.OK)
+
+		req := passkey.assert(t, begin.Options)
+		req.UserHandle = []byte(raider.UserID)
+		_, err = usersServer.FinishWebAuthnAssertion(ctx, req)
+		assertCode(t, err, codes.OK)
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		begin, err := usersServer.BeginWebAuthnAssertion(ctx, &pb.BeginWebAuthnAssertionRequest{Id: raider.UserID})
+		assertCode(t, err, codes.OK)
+
+		req := passkey.assert(t, begin.Options)
+		_, err = usersServer.FinishWebAuthnAssertion(ctx, req)
+		assertCode(t, err, codes.OK)
+
+		_, err = usersServer.FinishWebAuthnAssertion(ctx, req)
+		assertCode(t, err, codes.Unauthenticated)
+	})
+
+	t.Run("challenge of another user", func(t *testing.T) {
+		registerPasskey(t, usersServer, users["healer"].UserID)
+		begin, err := usersServer.BeginWebAuthnAssertion(ctx, &pb.BeginWebAuthnAssertionRequest{Login: "healer"})
+		assertCode(t, err, codes.OK)
+
+		_, err = usersServer.FinishWebAuthnAssertion(ctx, passkey.assert(t, begin.Options))
+		assertCode(t, err, codes.Unauthenticated)
+	})
+
+	t.Run("cloned credential", func(t *testing.T) {
+		begin, err := usersServer.BeginWebAuthnAssertion(ctx, &pb.BeginWebAuthnAssertionRequest{Login: "raider"})
+		assertCode(t, err, codes.OK)
+
+		passkey.signCount = 0
+		_, err = usersServer.FinishWebAuthnAssertion(ctx, passkey.assert(t, begin.Options))
+		assertCode(t, err, codes.Unauthenticated)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		usersServer, _, users := newTestServer(t, "tank")
+
+		// Users without credentials look like unknown users, both get a discoverable challenge
+		for _, req := range []*pb.BeginWebAuthnAssertionRequest{{Login: "tank"}, {Id: users["tank"].UserID}, {Login: "unknown"}, {Id: "unknown"}} {
+			begin, err := usersServer.BeginWebAuthnAssertion(ctx, req)
+			assertCode(t, err, codes.OK)
+
+			var options struct {
+				AllowCredentials []interface{} `json:"allowCredentials"`
+			}
+			err = json.Unmarshal([]byte(begin.Options), &options)
+			if err != nil || len(options.AllowCredentials) != 0 {
+				t.Errorf("got options %s for %v, want no allowed credentials", begin.Options, req)
+			}
+		}
+	})
+}
@@ -21,23 +21,34 @@ resource "aws_dynamodb_table" "users_dynamo_table" {
   write_capacity = 5
   read_capacity  = 5
 
+  # Expires sessions and other short-lived items stored next to the users
+  ttl {
+    attribute_name = "ttl"
+    enabled        = true
+  }
+
   attribute {
     name = "userID"
     type = "S"
   }
 
   attribute {
-    name = "login"
+    name = "canonicalLogin"
+    type = "S"
+  }
+
+  attribute {
+    name = "canonicalEmail"
     type = "S"
   }
 
   attribute {
-    name = "email"
+    name = "loginSearchShard"
     type = "S"
   }
 
   global_secondary_index {
-    hash_key        = "login"
+    hash_key        = "canonicalLogin"
     name            = "LoginIndex"
     projection_type = "ALL"
     write_capacity = 5
@@ -45,10 +56,20 @@ resource "aws_dynamodb_table" "users_dynamo_table" {
   }
 
   global_secondary_index {
-    hash_key        = "email"
+    hash_key        = "canonicalEmail"
     name            = "EmailIndex"
     projection_type = "ALL"
     write_capacity = 5
     read_capacity = 5
   }
+
+  # Login prefix search, sharded by the first two characters of the canonical login
+  global_secondary_index {
+    hash_key        = "loginSearchShard"
+    range_key       = "canonicalLogin"
+    name            = "LoginPrefixIndex"
+    projection_type = "ALL"
+    write_capacity = 5
+    read_capacity = 5
+  }
 }
\ No newline at end of file
@@ -0,0 +1,141 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/fxamacker/cbor/v2"
+	"math/big"
+)
+
+// COSE algorithm identifiers of the supported credential public keys, in order of preference.
+const (
+	AlgorithmEdDSA = -8
+	AlgorithmES256 = -7
+	AlgorithmRS256 = -257
+)
+
+var SupportedAlgorithms = []int{AlgorithmEdDSA, AlgorithmES256, AlgorithmRS256}
+
+// COSE key parameters (RFC 8152 section 13).
+const (
+	coseKeyType   = 1
+	coseAlgorithm = 3
+	// The meaning of negative labels depends on the key type.
+	coseCurveOrModulus     = -1
+	coseXOrExponent        = -2
+	coseY                  = -3
+	coseKeyTypeOKP         = 1
+	coseKeyTypeEC2         = 2
+	coseKeyTypeRSA         = 3
+	coseCurveP256          = 1
+	coseCurveEd25519       = 6
+	minRSAPublicKeyBits    = 2048
+	ecdsaP256CoordinateLen = 32
+)
+
+var ErrUnsupportedKey = errors.New("credential public key is not supported")
+
+type publicKey struct {
+	algorithm int
+	key       crypto.PublicKey
+}
+
+func parsePublicKey(encoded []byte) (publicKey, error) {
+	var params map[int]cbor.RawMessage
+	err := cbor.Unmarshal(encoded, &params)
+	if err != nil {
+		return publicKey{}, fmt.Errorf("%w: %v", ErrUnsupportedKey, err)
+	}
+
+	var keyType, algorithm int
+	err = decodeParams(params, map[int]interface{}{coseKeyType: &keyType, coseAlgorithm: &algorithm})
+	if err != nil {
+		return publicKey{}, err
+	}
+
+	switch {
+	case keyType == coseKeyTypeOKP && algorithm == AlgorithmEdDSA:
+		var curve int
+		var x []byte
+		err = decodeParams(params, map[int]interface{}{coseCurveOrModulus: &curve, coseXOrExponent: &x})
+		if err != nil {
+			return publicKey{}, err
+		}
+		if curve != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return publicKey{}, fmt.Errorf("%w: invalid Ed25519 key", ErrUnsupportedKey)
+		}
+		return publicKey{algorithm: algorithm, key: ed25519.PublicKey(x)}, nil
+
+	case keyType == coseKeyTypeEC2 && algorithm == AlgorithmES256:
+		var curve int
+		var x, y []byte
+		err = decodeParams(params, map[int]interface{}{coseCurveOrModulus: &curve, coseXOrExponent: &x, coseY: &y})
+		if err != nil {
+			return publicKey{}, err
+		}
+		if curve != coseCurveP256 || len(x) != ecdsaP256CoordinateLen || len(y) != ecdsaP256CoordinateLen {
+			return publicKey{}, fmt.Errorf("%w: invalid P-256 key", ErrUnsupportedKey)
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return publicKey{}, fmt.Errorf("%w: point is not on P-256", ErrUnsupportedKey)
+		}
+		return publicKey{algorithm: algorithm, key: key}, nil
+
+	case keyType == coseKeyTypeRSA && algorithm == AlgorithmRS256:
+		var n, e []byte
+		err = decodeParams(params, map[int]interface{}{coseCurveOrModulus: &n, coseXOrExponent: &e})
+		if err != nil {
+			return publicKey{}, err
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		if key.N.BitLen() < minRSAPublicKeyBits || key.E < 3 {
+			return publicKey{}, fmt.Errorf("%w: invalid RSA key", ErrUnsupportedKey)
+		}
+		return publicKey{algorithm: algorithm, key: key}, nil
+	}
+
+	return publicKey{}, fmt.Errorf("%w: key type %d with algorithm %d", ErrUnsupportedKey, keyType, algorithm)
+}
+
+func decodeParams(params map[int]cbor.RawMessage, values map[int]interface{}) error {
+	for label, value := range values {
+		raw, ok := params[label]
+		if !ok {
+			return fmt.Errorf("%w: missing parameter %d", ErrUnsupportedKey, label)
+		}
+
+		err := cbor.Unmarshal(raw, value)
+		if err != nil {
+			return fmt.Errorf("%w: parameter %d: %v", ErrUnsupportedKey, label, err)
+		}
+	}
+
+	return nil
+}
+
+func (k publicKey) verify(data, signature []byte) error {
+	var ok bool
+	switch key := k.key.(type) {
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(key, data, signature)
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		ok = ecdsa.VerifyASN1(key, digest[:], signature)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		ok = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	}
+
+	if !ok {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
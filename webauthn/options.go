@@ -0,0 +1,89 @@
+package webauthn
+
+import (
+	"encoding/json"
+)
+
+// ceremonyTimeout is how long browsers wait for the user, in milliseconds.
+const ceremonyTimeout = 300000
+
+type credentialParameter struct {
+	Type      string `json:"type"`
+	Algorithm int    `json:"alg"`
+}
+
+type credentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type creationOptions struct {
+	Challenge string `json:"challenge"`
+	RP        struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"rp"`
+	User struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		DisplayName string `json:"displayName"`
+	} `json:"user"`
+	PubKeyCredParams       []credentialParameter  `json:"pubKeyCredParams"`
+	ExcludeCredentials     []credentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection struct {
+		ResidentKey      string `json:"residentKey"`
+		UserVerification string `json:"userVerification"`
+	} `json:"authenticatorSelection"`
+	Attestation string `json:"attestation"`
+	Timeout     int    `json:"timeout"`
+}
+
+type requestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []credentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+	Timeout          int                    `json:"timeout"`
+}
+
+func credentialDescriptors(credentialIDs [][]byte) []credentialDescriptor {
+	descriptors := make([]credentialDescriptor, 0, len(credentialIDs))
+	for _, id := range credentialIDs {
+		descriptors = append(descriptors, credentialDescriptor{Type: "public-key", ID: EncodeID(id)})
+	}
+	return descriptors
+}
+
+// CreationOptions returns the JSON options of navigator.credentials.create() registering
+// a credential for a user, excluding the credentials the user already has.
+func (rp RelyingParty) CreationOptions(challenge string, userID []byte, userName string, excludeCredentialIDs [][]byte) ([]byte, error) {
+	var options creationOptions
+	options.Challenge = challenge
+	options.RP.ID = rp.ID
+	options.RP.Name = rp.Name
+	options.User.ID = EncodeID(userID)
+	options.User.Name = userName
+	options.User.DisplayName = userName
+	for _, algorithm := range SupportedAlgorithms {
+		options.PubKeyCredParams = append(options.PubKeyCredParams, credentialParameter{Type: "public-key", Algorithm: algorithm})
+	}
+	options.ExcludeCredentials = credentialDescriptors(excludeCredentialIDs)
+	options.AuthenticatorSelection.ResidentKey = "preferred"
+	options.AuthenticatorSelection.UserVerification = "required"
+	options.Attestation = "none"
+	options.Timeout = ceremonyTimeout
+
+	return json.Marshal(options)
+}
+
+// RequestOptions returns the JSON options of navigator.credentials.get(). When no
+// credentials are allowed, the authenticator offers its discoverable credentials.
+func (rp RelyingParty) RequestOptions(challenge string, allowCredentialIDs [][]byte) ([]byte, error) {
+	return json.Marshal(requestOptions{
+		Challenge:        challenge,
+		RPID:             rp.ID,
+		AllowCredentials: credentialDescriptors(allowCredentialIDs),
+		UserVerification: "required",
+		Timeout:          ceremonyTimeout,
+	})
+}
@@ -0,0 +1,243 @@
+// Package webauthn verifies WebAuthn (passkey) registrations and assertions server-side.
+// Only the "none" attestation format is supported: authenticators are trusted to be what
+// they claim, which is all passwordless sign-in needs.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	flagUserPresent            = 0x01
+	flagUserVerified           = 0x04
+	flagAttestedCredentialData = 0x40
+
+	// rpIDHash (32) + flags (1) + signCount (4)
+	authenticatorDataMinLength = 37
+)
+
+var (
+	ErrInvalidClientData        = errors.New("client data is invalid")
+	ErrInvalidAuthenticatorData = errors.New("authenticator data is invalid")
+	ErrUnsupportedAttestation   = errors.New("attestation format is not supported")
+	ErrInvalidSignature         = errors.New("signature is invalid")
+	// ErrSignCountRegressed means the authenticator's signature counter didn't increase,
+	// hinting the credential was cloned.
+	ErrSignCountRegressed = errors.New("signature counter did not increase")
+)
+
+// RelyingParty is the website credentials are scoped to.
+type RelyingParty struct {
+	// ID is the domain credentials are registered for, e.g. raidcomp.io.
+	ID   string
+	Name string
+	// Origins are the origins ceremonies may be performed from, e.g. https://raidcomp.io.
+	Origins []string
+}
+
+// Credential is a public key credential created by an authenticator.
+type Credential struct {
+	ID []byte
+	// PublicKey is the COSE encoded credential public key.
+	PublicKey []byte
+	SignCount uint32
+}
+
+// ClientData is the part of the client data JSON relevant to verification.
+type ClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// ParseClientData parses client data JSON so its challenge can be looked up before
+// verifying the ceremony.
+func ParseClientData(clientDataJSON []byte) (ClientData, error) {
+	var clientData ClientData
+	err := json.Unmarshal(clientDataJSON, &clientData)
+	if err != nil || clientData.Challenge == "" {
+		return ClientData{}, ErrInvalidClientData
+	}
+
+	return clientData, nil
+}
+
+func (rp RelyingParty) verifyClientData(clientDataJSON []byte, ceremonyType, challenge string) error {
+	clientData, err := ParseClientData(clientDataJSON)
+	if err != nil {
+		return err
+	}
+
+	if clientData.Type != ceremonyType {
+		return fmt.Errorf("%w: type is %s, expected %s", ErrInvalidClientData, clientData.Type, ceremonyType)
+	}
+
+	if clientData.Challenge != challenge {
+		return fmt.Errorf("%w: challenge does not match", ErrInvalidClientData)
+	}
+
+	for _, origin := range rp.Origins {
+		if clientData.Origin == origin {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: origin %s is not allowed", ErrInvalidClientData, clientData.Origin)
+}
+
+type authenticatorData struct {
+	flags     byte
+	signCount uint32
+	// credentialID and publicKey are only set when flagAttestedCredentialData is.
+	credentialID []byte
+	publicKey    []byte
+}
+
+func (rp RelyingParty) parseAuthenticatorData(data []byte) (authenticatorData, error) {
+	if len(data) < authenticatorDataMinLength {
+		return authenticatorData{}, ErrInvalidAuthenticatorData
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(data[:32], rpIDHash[:]) {
+		return authenticatorData{}, fmt.Errorf("%w: relying party ID does not match", ErrInvalidAuthenticatorData)
+	}
+
+	parsed := authenticatorData{
+		flags:     data[32],
+		signCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+
+	if parsed.flags&flagUserPresent == 0 {
+		return authenticatorData{}, fmt.Errorf("%w: user was not present", ErrInvalidAuthenticatorData)
+	}
+
+	// Passkeys log users in without a password, touching the authenticator is not enough
+	if parsed.flags&flagUserVerified == 0 {
+		return authenticatorData{}, fmt.Errorf("%w: user was not verified", ErrInvalidAuthenticatorData)
+	}
+
+	if parsed.flags&flagAttestedCredentialData == 0 {
+		return parsed, nil
+	}
+
+	// aaguid (16) + credentialIdLength (2) + credentialId + credentialPublicKey
+	rest := data[authenticatorDataMinLength:]
+	if len(rest) < 18 {
+		return authenticatorData{}, ErrInvalidAuthenticatorData
+	}
+
+	credentialIDLength := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if len(rest) < credentialIDLength {
+		return authenticatorData{}, ErrInvalidAuthenticatorData
+	}
+	parsed.credentialID = rest[:credentialIDLength]
+
+	// Extensions may follow the public key, so only its own CBOR item is kept
+	publicKeyAndExtensions := rest[credentialIDLength:]
+	var publicKey cbor.RawMessage
+	_, err := cbor.UnmarshalFirst(publicKeyAndExtensions, &publicKey)
+	if err != nil {
+		return authenticatorData{}, fmt.Errorf("%w: %v", ErrInvalidAuthenticatorData, err)
+	}
+	parsed.publicKey = publicKey
+
+	return parsed, nil
+}
+
+type attestationObject struct {
+	Format   string          `cbor:"fmt"`
+	AuthData []byte          `cbor:"authData"`
+	Stmt     cbor.RawMessage `cbor:"attStmt"`
+}
+
+// VerifyRegistration verifies the response of navigator.credentials.create() to a
+// challenge and returns the new credential.
+func (rp RelyingParty) VerifyRegistration(challenge string, clientDataJSON, attestation []byte) (Credential, error) {
+	err := rp.verifyClientData(clientDataJSON, "webauthn.create", challenge)
+	if err != nil {
+		return Credential{}, err
+	}
+
+	var object attestationObject
+	err = cbor.Unmarshal(attestation, &object)
+	if err != nil {
+		return Credential{}, fmt.Errorf("%w: %v", ErrUnsupportedAttestation, err)
+	}
+
+	if object.Format != "none" {
+		return Credential{}, fmt.Errorf("%w: %s", ErrUnsupportedAttestation, object.Format)
+	}
+
+	data, err := rp.parseAuthenticatorData(object.AuthData)
+	if err != nil {
+		return Credential{}, err
+	}
+
+	if data.credentialID == nil {
+		return Credential{}, fmt.Errorf("%w: no attested credential data", ErrInvalidAuthenticatorData)
+	}
+
+	// Unsupported key types are refused now rather than on every sign-in
+	_, err = parsePublicKey(data.publicKey)
+	if err != nil {
+		return Credential{}, err
+	}
+
+	return Credential{
+		ID:        data.credentialID,
+		PublicKey: data.publicKey,
+		SignCount: data.signCount,
+	}, nil
+}
+
+// VerifyAssertion verifies the response of navigator.credentials.get() to a challenge
+// made with credential, and returns the credential's new signature counter.
+func (rp RelyingParty) VerifyAssertion(challenge string, credential Credential, clientDataJSON, authData, signature []byte) (uint32, error) {
+	err := rp.verifyClientData(clientDataJSON, "webauthn.get", challenge)
+	if err != nil {
+		return 0, err
+	}
+
+	data, err := rp.parseAuthenticatorData(authData)
+	if err != nil {
+		return 0, err
+	}
+
+	publicKey, err := parsePublicKey(credential.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, authData...), clientDataHash[:]...)
+	err = publicKey.verify(signed, signature)
+	if err != nil {
+		return 0, err
+	}
+
+	// Authenticators without a counter, like most passkey providers, always report 0
+	if (data.signCount != 0 || credential.SignCount != 0) && data.signCount <= credential.SignCount {
+		return 0, ErrSignCountRegressed
+	}
+
+	return data.signCount, nil
+}
+
+// EncodeID encodes credential and user IDs the way they appear in JSON options.
+func EncodeID(id []byte) string {
+	return base64.RawURLEncoding.EncodeToString(id)
+}
+
+// DecodeID decodes IDs encoded by EncodeID.
+func DecodeID(id string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(id)
+}
@@ -0,0 +1,229 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"github.com/fxamacker/cbor/v2"
+	"testing"
+)
+
+var testRP = RelyingParty{ID: "raidcomp.io", Name: "Raidcomp", Origins: []string{"https://raidcomp.io"}}
+
+// testAuthenticator is a software authenticator with a single ES256 credential.
+type testAuthenticator struct {
+	credentialID []byte
+	key          *ecdsa.PrivateKey
+	signCount    uint32
+}
+
+func newTestAuthenticator(t *testing.T) *testAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	return &testAuthenticator{credentialID: []byte("credential-1"), key: key}
+}
+
+func (a *testAuthenticator) publicKey(t *testing.T) []byte {
+	encoded, err := cbor.Marshal(map[int]interface{}{
+		coseKeyType:        coseKeyTypeEC2,
+		coseAlgorithm:      AlgorithmES256,
+		coseCurveOrModulus: coseCurveP256,
+		coseXOrExponent:    a.key.X.FillBytes(make([]byte, 32)),
+		coseY:              a.key.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		t.Fatalf("encoding public key: %v", err)
+	}
+	return encoded
+}
+
+func (a *testAuthenticator) authData(rpID string, flags byte, attestedKey []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	if attestedKey != nil {
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.credentialID)))
+		data = append(data, a.credentialID...)
+		data = append(data, attestedKey...)
+	}
+	return data
+}
+
+func clientDataJSON(t *testing.T, ceremonyType, challenge, origin string) []byte {
+	data, err := json.Marshal(ClientData{Type: ceremonyType, Challenge: challenge, Origin: origin})
+	if err != nil {
+		t.Fatalf("encoding client data: %v", err)
+	}
+	return data
+}
+
+func (a *testAuthenticator) register(t *testing.T, format string) []byte {
+	attestation, err := cbor.Marshal(map[string]interface{}{
+		"fmt":      format,
+		"attStmt":  map[string]interface{}{},
+		"authData": a.authData(testRP.ID, flagUserPresent|flagUserVerified|flagAttestedCredentialData, a.publicKey(t)),
+	})
+	if err != nil {
+		t.Fatalf("encoding attestation: %v", err)
+	}
+	return attestation
+}
+
+func (a *testAuthenticator) sign(t *testing.T, authData, clientDataJSON []byte) []byte {
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatalf("signing: %v", err)
+	}
+	return signature
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	authenticator := newTestAuthenticator(t)
+	authenticator.signCount = 3
+
+	tests := []struct {
+		name       string
+		clientData []byte
+		format     string
+		wantErr    error
+	}{
+		{name: "valid", clientData: clientDataJSON(t, "webauthn.create", "challenge", "https://raidcomp.io"), format: "none"},
+		{name: "other challenge", clientData: clientDataJSON(t, "webauthn.create", "other", "https://raidcomp.io"), format: "none", wantErr: ErrInvalidClientData},
+		{name: "other origin", clientData: clientDataJSON(t, "webauthn.create", "challenge", "https://evil.io"), format: "none", wantErr: ErrInvalidClientData},
+		{name: "assertion client data", clientData: clientDataJSON(t, "webauthn.get", "challenge", "https://raidcomp.io"), format: "none", wantErr: ErrInvalidClientData},
+		{name: "attested format", clientData: clientDataJSON(t, "webauthn.create", "challenge", "https://raidcomp.io"), format: "packed", wantErr: ErrUnsupportedAttestation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credential, err := testRP.VerifyRegistration("challenge", tt.clientData, authenticator.register(t, tt.format))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got err %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if string(credential.ID) != string(authenticator.credentialID) || credential.SignCount != 3 {
+				t.Errorf("got credential %+v", credential)
+			}
+			if _, err := parsePublicKey(credential.PublicKey); err != nil {
+				t.Errorf("parsing registered public key: %v", err)
+			}
+		})
+	}
+
+	t.Run("other relying party", func(t *testing.T) {
+		otherRP := RelyingParty{ID: "evil.io", Origins: testRP.Origins}
+		_, err := otherRP.VerifyRegistration("challenge", clientDataJSON(t, "webauthn.create", "challenge", "https://raidcomp.io"), authenticator.register(t, "none"))
+		if !errors.Is(err, ErrInvalidAuthenticatorData) {
+			t.Errorf("got err %v, want %v", err, ErrInvalidAuthenticatorData)
+		}
+	})
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	authenticator := newTestAuthenticator(t)
+	credential := Credential{ID: authenticator.credentialID, PublicKey: authenticator.publicKey(t), SignCount: 5}
+	clientData := clientDataJSON(t, "webauthn.get", "challenge", "https://raidcomp.io")
+
+	t.Run("valid", func(t *testing.T) {
+		authenticator.signCount = 6
+		authData := authenticator.authData(testRP.ID, flagUserPresent|flagUserVerified, nil)
+
+		signCount, err := testRP.VerifyAssertion("challenge", credential, clientData, authData, authenticator.sign(t, authData, clientData))
+		if err != nil || signCount != 6 {
+			t.Errorf("got sign count %d (err: %v), want 6", signCount, err)
+		}
+	})
+
+	t.Run("tampered authenticator data", func(t *testing.T) {
+		authenticator.signCount = 6
+		authData := authenticator.authData(testRP.ID, flagUserPresent|flagUserVerified, nil)
+		signature := authenticator.sign(t, authData, clientData)
+		authData[36] = 7
+
+		_, err := testRP.VerifyAssertion("challenge", credential, clientData, authData, signature)
+		if !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("got err %v, want %v", err, ErrInvalidSignature)
+		}
+	})
+
+	t.Run("user not present", func(t *testing.T) {
+		authenticator.signCount = 6
+		authData := authenticator.authData(testRP.ID, 0, nil)
+
+		_, err := testRP.VerifyAssertion("challenge", credential, clientData, authData, authenticator.sign(t, authData, clientData))
+		if !errors.Is(err, ErrInvalidAuthenticatorData) {
+			t.Errorf("got err %v, want %v", err, ErrInvalidAuthenticatorData)
+		}
+	})
+
+	t.Run("user not verified", func(t *testing.T) {
+		authenticator.signCount = 6
+		authData := authenticator.authData(testRP.ID, flagUserPresent, nil)
+
+		_, err := testRP.VerifyAssertion("challenge", credential, clientData, authData, authenticator.sign(t, authData, clientData))
+		if !errors.Is(err, ErrInvalidAuthenticatorData) {
+			t.Errorf("got err %v, want %v", err, ErrInvalidAuthenticatorData)
+		}
+	})
+
+	t.Run("sign count regressed", func(t *testing.T) {
+		authenticator.signCount = 5
+		authData := authenticator.authData(testRP.ID, flagUserPresent|flagUserVerified, nil)
+
+		_, err := testRP.VerifyAssertion("challenge", credential, clientData, authData, authenticator.sign(t, authData, clientData))
+		if !errors.Is(err, ErrSignCountRegressed) {
+			t.Errorf("got err %v, want %v", err, ErrSignCountRegressed)
+		}
+	})
+
+	t.Run("no sign count", func(t *testing.T) {
+		authenticator.signCount = 0
+		authData := authenticator.authData(testRP.ID, flagUserPresent|flagUserVerified, nil)
+		counterless := Credential{ID: credential.ID, PublicKey: credential.PublicKey}
+
+		_, err := testRP.VerifyAssertion("challenge", counterless, clientData, authData, authenticator.sign(t, authData, clientData))
+		if err != nil {
+			t.Errorf("got err %v for an authenticator without a counter", err)
+		}
+	})
+}
+
+func TestCreationOptions(t *testing.T) {
+	encoded, err := testRP.CreationOptions("challenge", []byte("user-1"), "raider", [][]byte{[]byte("credential-1")})
+	if err != nil {
+		t.Fatalf("encoding options: %v", err)
+	}
+
+	var options creationOptions
+	err = json.Unmarshal(encoded, &options)
+	if err != nil {
+		t.Fatalf("decoding options: %v", err)
+	}
+
+	if options.Challenge != "challenge" || options.RP.ID != testRP.ID || options.User.ID != EncodeID([]byte("user-1")) {
+		t.Errorf("got options %s", encoded)
+	}
+	if len(options.ExcludeCredentials) != 1 || options.ExcludeCredentials[0].ID != EncodeID([]byte("credential-1")) {
+		t.Errorf("got excluded credentials %+v", options.ExcludeCredentials)
+	}
+	if len(options.PubKeyCredParams) != len(SupportedAlgorithms) {
+		t.Errorf("got credential parameters %+v", options.PubKeyCredParams)
+	}
+	if options.AuthenticatorSelection.UserVerification != "required" {
+		t.Errorf("got user verification %q, want required", options.AuthenticatorSelection.UserVerification)
+	}
+}